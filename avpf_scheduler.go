@@ -0,0 +1,316 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"bytes"
+	"math/rand"
+	"time"
+)
+
+// FeedbackMode is the RTCP feedback operation mode of RFC 4585 Section 3.4.
+type FeedbackMode int
+
+const (
+	// FeedbackModeImmediate is used while the group is small enough that
+	// every event can be reported without delay. The scheduler uses it for
+	// point-to-point sessions, where T_dither_max is zero.
+	FeedbackModeImmediate FeedbackMode = iota
+	// FeedbackModeEarly allows sending feedback in early RTCP packets ahead
+	// of the next regular report, subject to dithering and allow_early.
+	FeedbackModeEarly
+	// FeedbackModeRegular only sends feedback with regular RTCP reports.
+	FeedbackModeRegular
+)
+
+func (m FeedbackMode) String() string {
+	switch m {
+	case FeedbackModeImmediate:
+		return "Immediate Feedback"
+	case FeedbackModeEarly:
+		return "Early RTCP"
+	case FeedbackModeRegular:
+		return "Regular RTCP"
+	}
+
+	return "invalid feedback mode"
+}
+
+// ditherFactor is the factor l used to derive T_dither_max from T_rr in
+// multiparty sessions, RFC 4585 Section 3.4.
+const ditherFactor = 0.5
+
+// AVPFTransmission is a compound packet the AVPFScheduler decided to send.
+type AVPFTransmission struct {
+	// Regular is set for a regular RTCP report, which must carry the full
+	// set of reports. Otherwise this is an early (or, when the regular
+	// report was suppressed by T_rr_interval, a minimal) compound packet
+	// carrying only the feedback.
+	Regular bool
+	// Feedback messages to include in the compound packet.
+	Feedback []Packet
+}
+
+// AVPFScheduler implements the feedback timing rules of the Extended RTP
+// Profile for RTCP-Based Feedback (RTP/AVPF), RFC 4585 Section 3.5. It
+// decides whether queued feedback such as PictureLossIndication,
+// TransportLayerNack or FullIntraRequest may be sent right away in an early
+// RTCP packet or has to wait for the next regular report, and suppresses
+// feedback that another member already sent.
+//
+// The scheduler does not run any timers; time is passed in by the caller,
+// which should call Poll at NextTransmission.
+type AVPFScheduler struct {
+	// Minimal interval between regular RTCP packets, T_rr_interval, as
+	// negotiated with the SDP "trr-int" parameter. Zero disables it.
+	MinimalInterval time.Duration
+	// RegularOnly disables early RTCP packets, so that all feedback is
+	// sent with regular reports.
+	RegularOnly bool
+	// Random returns a pseudo-random number in [0.0, 1.0) used for
+	// dithering. It defaults to math/rand.Float64.
+	Random func() float64
+
+	interval    time.Duration
+	members     int
+	allowEarly  bool
+	tp          time.Time
+	tn          time.Time
+	lastRegular time.Time
+	early       bool
+	te          time.Time
+	pending     []Packet
+}
+
+// NewAVPFScheduler creates an AVPFScheduler at time now with T_rr, the
+// interval between regular RTCP packets, as calculated for the session by
+// RFC 3550 rules. The first regular report is scheduled one interval later.
+func NewAVPFScheduler(interval time.Duration, now time.Time) *AVPFScheduler {
+	return &AVPFScheduler{
+		Random:     rand.Float64, //nolint:gosec // G404, dithering does not need a secure source
+		interval:   interval,
+		members:    2,
+		allowEarly: true,
+		tp:         now,
+		tn:         now.Add(interval),
+	}
+}
+
+// SetInterval updates T_rr, which is used for the next regular report
+// scheduled after the current one.
+func (s *AVPFScheduler) SetInterval(interval time.Duration) {
+	s.interval = interval
+}
+
+// SetMembers updates the number of session members, including ourselves.
+func (s *AVPFScheduler) SetMembers(members int) {
+	s.members = max(members, 1)
+}
+
+// Mode returns the current feedback mode.
+func (s *AVPFScheduler) Mode() FeedbackMode {
+	switch {
+	case s.RegularOnly:
+		return FeedbackModeRegular
+	case s.members <= 2:
+		return FeedbackModeImmediate
+	default:
+		return FeedbackModeEarly
+	}
+}
+
+// AllowEarly reports whether an early RTCP packet may be sent, that is
+// whether none has been sent since the last regular report.
+func (s *AVPFScheduler) AllowEarly() bool {
+	return s.allowEarly && !s.RegularOnly
+}
+
+// DitherMax returns T_dither_max, the maximum random delay of an early
+// RTCP packet: zero for point-to-point sessions, l * T_rr otherwise.
+func (s *AVPFScheduler) DitherMax() time.Duration {
+	if s.members <= 2 {
+		return 0
+	}
+
+	return time.Duration(ditherFactor * float64(s.interval))
+}
+
+// NextTransmission returns when Poll should be called next, which is the
+// time of the scheduled early packet if there is one, or of the next
+// regular report otherwise.
+func (s *AVPFScheduler) NextTransmission() time.Time {
+	if s.early && s.te.Before(s.tn) {
+		return s.te
+	}
+
+	return s.tn
+}
+
+// Enqueue queues a feedback message detected at time now following the
+// early feedback algorithm of RFC 4585 Section 3.5.2, and returns the time
+// at which it will be sent. early is true if it was scheduled in an early
+// RTCP packet rather than with the next regular report. Feedback identical
+// to a message already pending is merged with it.
+func (s *AVPFScheduler) Enqueue(pkt Packet, now time.Time) (sendAt time.Time, early bool) {
+	if !s.isPending(pkt) {
+		s.pending = append(s.pending, pkt)
+	}
+
+	if s.early {
+		return s.te, true
+	}
+
+	if !s.AllowEarly() {
+		return s.tn, false
+	}
+
+	// A regular report due within T_dither_max carries the feedback.
+	dither := s.DitherMax()
+	if s.tn.Sub(now) <= dither {
+		return s.tn, false
+	}
+
+	te := now
+	if dither > 0 {
+		te = now.Add(time.Duration(s.random() * float64(dither)))
+	}
+
+	s.early = true
+	s.te = te
+	s.allowEarly = false
+	// Compensate for the early packet by skipping one regular interval.
+	s.tn = s.tp.Add(2 * s.interval)
+
+	return te, true
+}
+
+// FeedbackReceived removes pending feedback that was already sent by
+// another member of the session, as a receiver seeing the same feedback
+// from someone else must not repeat it.
+func (s *AVPFScheduler) FeedbackReceived(pkt Packet) {
+	pending := s.pending[:0]
+	for _, p := range s.pending {
+		if !feedbackCovers(pkt, p) {
+			pending = append(pending, p)
+		}
+	}
+	clear(s.pending[len(pending):])
+	s.pending = pending
+
+	if len(s.pending) == 0 {
+		s.early = false
+	}
+}
+
+// Pending returns the feedback messages waiting to be sent.
+func (s *AVPFScheduler) Pending() []Packet {
+	return append([]Packet(nil), s.pending...)
+}
+
+// Poll returns the compound packet to send at time now, if any. Regular
+// reports follow RFC 4585 Section 3.5.3: when T_rr_interval has not elapsed
+// since the last regular report was sent, the report is suppressed and only
+// pending feedback is sent in a minimal compound packet.
+func (s *AVPFScheduler) Poll(now time.Time) (AVPFTransmission, bool) {
+	if s.early && !now.Before(s.te) && s.te.Before(s.tn) {
+		s.early = false
+		if len(s.pending) == 0 {
+			return AVPFTransmission{}, false
+		}
+
+		return AVPFTransmission{Feedback: s.takePending()}, true
+	}
+
+	if now.Before(s.tn) {
+		return AVPFTransmission{}, false
+	}
+
+	regular := s.MinimalInterval == 0 || s.lastRegular.IsZero() || s.tn.Sub(s.lastRegular) >= s.MinimalInterval
+	if regular {
+		s.lastRegular = s.tn
+	}
+
+	s.tp = s.tn
+	s.tn = s.tp.Add(s.interval)
+	s.allowEarly = true
+	s.early = false
+
+	if !regular && len(s.pending) == 0 {
+		return AVPFTransmission{}, false
+	}
+
+	return AVPFTransmission{Regular: regular, Feedback: s.takePending()}, true
+}
+
+func (s *AVPFScheduler) takePending() []Packet {
+	pending := s.pending
+	s.pending = nil
+
+	return pending
+}
+
+func (s *AVPFScheduler) isPending(pkt Packet) bool {
+	for _, p := range s.pending {
+		if feedbackCovers(p, pkt) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *AVPFScheduler) random() float64 {
+	if s.Random == nil {
+		return rand.Float64() //nolint:gosec // G404
+	}
+
+	return s.Random()
+}
+
+// feedbackCovers reports whether feedback message a makes b redundant,
+// regardless of the member that sent it. A NACK covers another one if it
+// requests every packet of it; other messages must be identical apart from
+// the sender SSRC.
+func feedbackCovers(a, b Packet) bool {
+	if nackA, ok := a.(*TransportLayerNack); ok {
+		nackB, ok := b.(*TransportLayerNack)
+		if !ok || nackA.MediaSSRC != nackB.MediaSSRC {
+			return false
+		}
+
+		requested := map[uint16]struct{}{}
+		for _, pair := range nackA.Nacks {
+			pair.Range(func(seq uint16) bool {
+				requested[seq] = struct{}{}
+
+				return true
+			})
+		}
+		covered := true
+		for _, pair := range nackB.Nacks {
+			pair.Range(func(seq uint16) bool {
+				_, covered = requested[seq]
+
+				return covered
+			})
+			if !covered {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	// Feedback messages carry the header and sender SSRC in their first
+	// eight octets.
+	const senderSSRCEnd = headerLength + ssrcLength
+	rawA, errA := a.Marshal()
+	rawB, errB := b.Marshal()
+	if errA != nil || errB != nil || len(rawA) < senderSSRCEnd || len(rawB) < senderSSRCEnd {
+		return false
+	}
+
+	return bytes.Equal(rawA[:headerLength], rawB[:headerLength]) &&
+		bytes.Equal(rawA[senderSSRCEnd:], rawB[senderSSRCEnd:])
+}
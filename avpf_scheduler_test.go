@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAVPFSchedulerPointToPoint(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	sched := NewAVPFScheduler(time.Second, now)
+	assert.Equal(t, FeedbackModeImmediate, sched.Mode())
+	assert.Equal(t, time.Duration(0), sched.DitherMax())
+
+	// A PLI detected at t0 is sent immediately in an early packet.
+	t0 := now.Add(300 * time.Millisecond)
+	pli := &PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}
+	sendAt, early := sched.Enqueue(pli, t0)
+	assert.True(t, early)
+	assert.Equal(t, t0, sendAt)
+	assert.False(t, sched.AllowEarly())
+
+	// The next regular report is pushed out to tp + 2*T_rr.
+	assert.Equal(t, t0, sched.NextTransmission())
+	tx, ok := sched.Poll(t0)
+	assert.True(t, ok)
+	assert.Equal(t, AVPFTransmission{Feedback: []Packet{pli}}, tx)
+	assert.Equal(t, now.Add(2*time.Second), sched.NextTransmission())
+
+	// Further feedback has to wait for the regular report.
+	nack := &TransportLayerNack{SenderSSRC: 1, MediaSSRC: 2, Nacks: []NackPair{{PacketID: 10}}}
+	sendAt, early = sched.Enqueue(nack, now.Add(500*time.Millisecond))
+	assert.False(t, early)
+	assert.Equal(t, now.Add(2*time.Second), sendAt)
+
+	_, ok = sched.Poll(now.Add(time.Second))
+	assert.False(t, ok)
+
+	tx, ok = sched.Poll(now.Add(2 * time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, AVPFTransmission{Regular: true, Feedback: []Packet{nack}}, tx)
+
+	// The regular report re-enables early packets.
+	assert.True(t, sched.AllowEarly())
+	assert.Equal(t, now.Add(3*time.Second), sched.NextTransmission())
+}
+
+func TestAVPFSchedulerMultiparty(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	sched := NewAVPFScheduler(4*time.Second, now)
+	sched.Random = constantRandom(0.5)
+	sched.SetMembers(10)
+	assert.Equal(t, FeedbackModeEarly, sched.Mode())
+
+	// T_dither_max = l * T_rr = 2s.
+	assert.Equal(t, 2*time.Second, sched.DitherMax())
+
+	// An event at t0 = 1s is dithered by 0.5 * 2s.
+	pli := &PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}
+	sendAt, early := sched.Enqueue(pli, now.Add(time.Second))
+	assert.True(t, early)
+	assert.Equal(t, now.Add(2*time.Second), sendAt)
+
+	// Another member sends the same PLI first, so ours is suppressed.
+	sched.FeedbackReceived(&PictureLossIndication{SenderSSRC: 3, MediaSSRC: 2})
+	assert.Empty(t, sched.Pending())
+	_, ok := sched.Poll(now.Add(2 * time.Second))
+	assert.False(t, ok)
+}
+
+func TestAVPFSchedulerDitherPastRegular(t *testing.T) {
+	// The choice between early and regular feedback does not depend on the
+	// random draw.
+	for _, random := range []float64{0, 0.5, 0.99} {
+		now := time.Unix(1700000000, 0)
+		sched := NewAVPFScheduler(4*time.Second, now)
+		sched.Random = constantRandom(random)
+		sched.SetMembers(10)
+
+		// tn = 4s is within T_dither_max = 2s of t0 = 3s, so the feedback
+		// goes with the regular report and allow_early is untouched.
+		sendAt, early := sched.Enqueue(&PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}, now.Add(3*time.Second))
+		assert.False(t, early, random)
+		assert.Equal(t, now.Add(4*time.Second), sendAt, random)
+		assert.True(t, sched.AllowEarly(), random)
+
+		// tn = 4s is more than T_dither_max after t0 = 1s, so an early
+		// packet is sent within T_dither_max.
+		sched = NewAVPFScheduler(4*time.Second, now)
+		sched.Random = constantRandom(random)
+		sched.SetMembers(10)
+		sendAt, early = sched.Enqueue(&PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}, now.Add(time.Second))
+		assert.True(t, early, random)
+		assert.Equal(t, now.Add(time.Second+time.Duration(random*float64(2*time.Second))), sendAt, random)
+	}
+}
+
+func TestAVPFSchedulerDeduplication(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	sched := NewAVPFScheduler(time.Second, now)
+	sched.RegularOnly = true
+	assert.Equal(t, FeedbackModeRegular, sched.Mode())
+
+	nack := &TransportLayerNack{SenderSSRC: 1, MediaSSRC: 2, Nacks: NackPairsFromSequenceNumbers([]uint16{10, 11, 12})}
+	fir := &FullIntraRequest{SenderSSRC: 1, MediaSSRC: 2, FIR: []FIREntry{{SSRC: 2, SequenceNumber: 1}}}
+	for _, pkt := range []Packet{
+		nack,
+		fir,
+		// Already covered by the pending NACK.
+		&TransportLayerNack{SenderSSRC: 1, MediaSSRC: 2, Nacks: []NackPair{{PacketID: 11}}},
+		// Identical FIR.
+		&FullIntraRequest{SenderSSRC: 1, MediaSSRC: 2, FIR: []FIREntry{{SSRC: 2, SequenceNumber: 1}}},
+	} {
+		_, early := sched.Enqueue(pkt, now)
+		assert.False(t, early)
+	}
+	assert.Equal(t, []Packet{nack, fir}, sched.Pending())
+
+	// A NACK from another member only covering part of ours does not
+	// suppress it.
+	sched.FeedbackReceived(&TransportLayerNack{SenderSSRC: 3, MediaSSRC: 2, Nacks: []NackPair{{PacketID: 10}}})
+	assert.Equal(t, []Packet{nack, fir}, sched.Pending())
+
+	sched.FeedbackReceived(&TransportLayerNack{
+		SenderSSRC: 3, MediaSSRC: 2,
+		Nacks: NackPairsFromSequenceNumbers([]uint16{9, 10, 11, 12}),
+	})
+	assert.Equal(t, []Packet{fir}, sched.Pending())
+
+	// A FIR with another sequence number is a new request.
+	sched.FeedbackReceived(&FullIntraRequest{SenderSSRC: 3, MediaSSRC: 2, FIR: []FIREntry{{SSRC: 2, SequenceNumber: 2}}})
+	assert.Equal(t, []Packet{fir}, sched.Pending())
+}
+
+func TestAVPFSchedulerMinimalInterval(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	sched := NewAVPFScheduler(time.Second, now)
+	sched.MinimalInterval = 3 * time.Second
+
+	tx, ok := sched.Poll(now.Add(time.Second))
+	assert.True(t, ok)
+	assert.True(t, tx.Regular)
+
+	// Regular reports within T_rr_interval of the last one are suppressed.
+	_, ok = sched.Poll(now.Add(2 * time.Second))
+	assert.False(t, ok)
+
+	// Unless feedback is pending, which goes out in a minimal packet.
+	sched.RegularOnly = true
+	sched.Enqueue(&PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}, now.Add(2500*time.Millisecond))
+	tx, ok = sched.Poll(now.Add(3 * time.Second))
+	assert.True(t, ok)
+	assert.False(t, tx.Regular)
+	assert.Len(t, tx.Feedback, 1)
+
+	tx, ok = sched.Poll(now.Add(4 * time.Second))
+	assert.True(t, ok)
+	assert.True(t, tx.Regular)
+	assert.Empty(t, tx.Feedback)
+}
+
+func TestFeedbackModeString(t *testing.T) {
+	assert.Equal(t, "Immediate Feedback", FeedbackModeImmediate.String())
+	assert.Equal(t, "Early RTCP", FeedbackModeEarly.String())
+	assert.Equal(t, "Regular RTCP", FeedbackModeRegular.String())
+	assert.Equal(t, "invalid feedback mode", FeedbackMode(42).String())
+}
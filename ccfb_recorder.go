@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Arrival time offsets of a CCFeedbackMetricBlock, RFC 8888 Section 3.1.
+const (
+	// ccfbMaxArrivalTimeOffset is the largest offset that can be reported.
+	ccfbMaxArrivalTimeOffset = 0x1FFD
+	// ccfbArrivalTimeOverRange reports an offset larger than
+	// ccfbMaxArrivalTimeOffset.
+	ccfbArrivalTimeOverRange = 0x1FFE
+	// ccfbArrivalTimeUnavailable reports an arrival after the Report
+	// Timestamp.
+	ccfbArrivalTimeUnavailable = 0x1FFF
+	// compactNTPPerATO is the number of 1/65536 seconds in 1/1024 seconds.
+	compactNTPPerATO = 64
+)
+
+const defaultCCFBMTU = 1200
+
+// CCFBRecorder records the RTP packets received on a set of streams and
+// builds the CCFeedbackReports reporting them, as an RFC 8888 receiver does.
+//
+// Each report carries one CCFeedbackReportBlock per stream that received
+// packets since the previous report, covering the sequence numbers from the
+// first packet not yet reported to the highest received. A packet arriving
+// after a newer one has been reported extends the next block back to it.
+// Arrival time offsets too large to be represented are reported as
+// over-range (0x1FFE), and arrivals after the Report Timestamp as
+// unavailable (0x1FFF).
+//
+// As recommended by RFC 8888 Section 3.1, when the report would exceed MTU
+// the oldest packets of the largest blocks are left out, as the most recent
+// ones are the most useful for congestion control.
+type CCFBRecorder struct {
+	// SSRC of the sender of the feedback.
+	SenderSSRC uint32
+	// MTU is the maximum size of a CCFeedbackReport.
+	MTU int
+
+	streams map[uint32]*arrivalWindow
+}
+
+// NewCCFBRecorder creates a CCFBRecorder sending reports from senderSSRC.
+func NewCCFBRecorder(senderSSRC uint32) *CCFBRecorder {
+	return &CCFBRecorder{
+		SenderSSRC: senderSSRC,
+		MTU:        defaultCCFBMTU,
+		streams:    map[uint32]*arrivalWindow{},
+	}
+}
+
+// Record records the arrival at time arrival of the RTP packet with
+// sequence number seq on the stream ssrc, marked with ecn. Duplicates are
+// ignored.
+func (r *CCFBRecorder) Record(ssrc uint32, seq uint16, arrival time.Time, ecn ECN) {
+	stream, ok := r.streams[ssrc]
+	if !ok {
+		stream = newArrivalWindow(maxMetricBlocks)
+		r.streams[ssrc] = stream
+	}
+	stream.record(seq, packetArrival{at: arrival, ecn: ecn})
+}
+
+type ccfbBlockRange struct {
+	ssrc       uint32
+	start, end int64
+}
+
+// BuildReport returns the CCFeedbackReport to send at time now, or nil if no
+// packet has been received since the previous report.
+func (r *CCFBRecorder) BuildReport(now time.Time) *CCFeedbackReport {
+	var ranges []*ccfbBlockRange
+	for ssrc, stream := range r.streams {
+		if stream.pending() {
+			ranges = append(ranges, &ccfbBlockRange{ssrc: ssrc, start: stream.start, end: stream.highest})
+		}
+	}
+	slices.SortFunc(ranges, func(a, b *ccfbBlockRange) int { return cmp.Compare(a.ssrc, b.ssrc) })
+
+	for len(ranges) > 0 && ccfbReportSize(ranges) > r.MTU {
+		largest := ranges[0]
+		for _, block := range ranges[1:] {
+			if block.end-block.start > largest.end-largest.start {
+				largest = block
+			}
+		}
+		if largest.start < largest.end {
+			largest.start++
+		} else {
+			// The streams left out are reported next time.
+			ranges = ranges[:len(ranges)-1]
+		}
+	}
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	report := &CCFeedbackReport{
+		SenderSSRC:      r.SenderSSRC,
+		ReportTimestamp: toCompactNTP(toNTPTime(now)),
+	}
+	for _, block := range ranges {
+		stream := r.streams[block.ssrc]
+		reportBlock := CCFeedbackReportBlock{
+			MediaSSRC:     block.ssrc,
+			BeginSequence: uint16(block.start), //nolint:gosec // G115
+			MetricBlocks:  make([]CCFeedbackMetricBlock, 0, block.end-block.start+1),
+		}
+		for s := block.start; s <= block.end; s++ {
+			var metric CCFeedbackMetricBlock
+			if arrival, ok := stream.arrivals[s]; ok {
+				metric = CCFeedbackMetricBlock{
+					Received:          true,
+					ECN:               arrival.ecn,
+					ArrivalTimeOffset: arrivalTimeOffset(report.ReportTimestamp, arrival.at),
+				}
+			}
+			reportBlock.MetricBlocks = append(reportBlock.MetricBlocks, metric)
+		}
+		report.ReportBlocks = append(report.ReportBlocks, reportBlock)
+		stream.start = block.end + 1
+	}
+
+	return report
+}
+
+// arrivalTimeOffset returns the offset of arrival before the Report
+// Timestamp reportTimestamp, in 1/1024 seconds.
+func arrivalTimeOffset(reportTimestamp uint32, arrival time.Time) uint16 {
+	offset := int32(reportTimestamp - toCompactNTP(toNTPTime(arrival))) //nolint:gosec // G115
+	switch {
+	case offset < 0:
+		return ccfbArrivalTimeUnavailable
+	case offset/compactNTPPerATO > ccfbMaxArrivalTimeOffset:
+		return ccfbArrivalTimeOverRange
+	}
+
+	return uint16(offset / compactNTPPerATO) //nolint:gosec // G115
+}
+
+func ccfbReportSize(ranges []*ccfbBlockRange) int {
+	size := reportBlockOffset + reportTimestampLength
+	for _, block := range ranges {
+		metricBlocks := int(block.end - block.start + 1)
+		size += reportsOffset + (metricBlocks+metricBlocks%2)*metricBlockLength
+	}
+
+	return size
+}
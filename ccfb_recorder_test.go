@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ccfbUnit is 8/1024 seconds, exactly representable in NTP format.
+const ccfbUnit = 7812500 * time.Nanosecond
+
+func buildCCFB(t *testing.T, recorder *CCFBRecorder, now time.Time) *CCFeedbackReport {
+	t.Helper()
+
+	report := recorder.BuildReport(now)
+	if report == nil {
+		return nil
+	}
+
+	raw, err := report.Marshal()
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(raw), recorder.MTU)
+	decoded := &CCFeedbackReport{}
+	assert.NoError(t, decoded.Unmarshal(raw))
+	assert.Equal(t, report, decoded)
+
+	return report
+}
+
+func TestCCFBRecorder(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	recorder := NewCCFBRecorder(0x1)
+
+	recorder.Record(0xB, 65535, now.Add(-4*ccfbUnit), ECNNonECT)
+	recorder.Record(0xB, 1, now.Add(-ccfbUnit), ECNCE)
+	recorder.Record(0xA, 10, now.Add(-2*ccfbUnit), ECNECT0)
+	recorder.Record(0xA, 10, now, ECNECT0)
+
+	assert.Equal(t, &CCFeedbackReport{
+		SenderSSRC:      0x1,
+		ReportTimestamp: toCompactNTP(toNTPTime(now)),
+		ReportBlocks: []CCFeedbackReportBlock{
+			{
+				MediaSSRC:     0xA,
+				BeginSequence: 10,
+				MetricBlocks: []CCFeedbackMetricBlock{
+					{Received: true, ECN: ECNECT0, ArrivalTimeOffset: 16},
+				},
+			},
+			{
+				MediaSSRC:     0xB,
+				BeginSequence: 65535,
+				MetricBlocks: []CCFeedbackMetricBlock{
+					{Received: true, ECN: ECNNonECT, ArrivalTimeOffset: 32},
+					{},
+					{Received: true, ECN: ECNCE, ArrivalTimeOffset: 8},
+				},
+			},
+		},
+	}, buildCCFB(t, recorder, now))
+	assert.Nil(t, recorder.BuildReport(now))
+
+	// The next block starts after the previous one, unless a late packet
+	// extends it back.
+	now = now.Add(100 * time.Millisecond)
+	recorder.Record(0xA, 12, now, ECNECT0)
+	recorder.Record(0xB, 0, now, ECNECT0)
+	report := buildCCFB(t, recorder, now)
+	assert.Equal(t, uint16(11), report.ReportBlocks[0].BeginSequence)
+	assert.Equal(t, []CCFeedbackMetricBlock{{}, {Received: true, ECN: ECNECT0}}, report.ReportBlocks[0].MetricBlocks)
+	assert.Equal(t, uint16(0), report.ReportBlocks[1].BeginSequence)
+	assert.Equal(t, []CCFeedbackMetricBlock{
+		{Received: true, ECN: ECNECT0},
+		{Received: true, ECN: ECNCE, ArrivalTimeOffset: 110},
+	}, report.ReportBlocks[1].MetricBlocks)
+}
+
+func TestCCFBRecorderArrivalTimeOffset(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	recorder := NewCCFBRecorder(0x1)
+
+	recorder.Record(0xA, 0, now.Add(-8189*ccfbUnit/8), ECNNonECT)
+	recorder.Record(0xA, 1, now.Add(-8190*ccfbUnit/8), ECNNonECT)
+	recorder.Record(0xA, 2, now.Add(-time.Minute), ECNNonECT)
+	recorder.Record(0xA, 3, now.Add(time.Millisecond), ECNNonECT)
+
+	report := buildCCFB(t, recorder, now)
+	var offsets []uint16
+	for _, metric := range report.ReportBlocks[0].MetricBlocks {
+		offsets = append(offsets, metric.ArrivalTimeOffset)
+	}
+	assert.Equal(t, []uint16{0x1FFD, 0x1FFE, 0x1FFE, 0x1FFF}, offsets)
+}
+
+func TestCCFBRecorderMTU(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	recorder := NewCCFBRecorder(0x1)
+	recorder.MTU = 100
+
+	for seq := uint16(0); seq < 100; seq++ {
+		recorder.Record(0xA, seq, now, ECNNonECT)
+	}
+	for seq := uint16(0); seq < 10; seq++ {
+		recorder.Record(0xB, seq, now, ECNNonECT)
+	}
+
+	// The oldest packets of the largest block are left out: 12 bytes of
+	// header and 2 block headers leave room for 36 metric blocks.
+	report := buildCCFB(t, recorder, now)
+	assert.Equal(t, 100, report.MarshalSize())
+	assert.Equal(t, uint16(74), report.ReportBlocks[0].BeginSequence)
+	assert.Len(t, report.ReportBlocks[0].MetricBlocks, 26)
+	assert.Equal(t, uint16(0), report.ReportBlocks[1].BeginSequence)
+	assert.Len(t, report.ReportBlocks[1].MetricBlocks, 10)
+
+	// Streams that do not fit at all are reported next time.
+	recorder.MTU = 28
+	recorder.Record(0xA, 100, now, ECNNonECT)
+	recorder.Record(0xB, 10, now, ECNNonECT)
+	report = buildCCFB(t, recorder, now)
+	assert.Len(t, report.ReportBlocks, 1)
+	assert.Equal(t, uint32(0xA), report.ReportBlocks[0].MediaSSRC)
+	report = buildCCFB(t, recorder, now)
+	assert.Len(t, report.ReportBlocks, 1)
+	assert.Equal(t, uint32(0xB), report.ReportBlocks[0].MediaSSRC)
+
+	recorder.MTU = 20
+	recorder.Record(0xA, 101, now, ECNNonECT)
+	assert.Nil(t, recorder.BuildReport(now))
+}
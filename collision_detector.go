@@ -0,0 +1,319 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"net"
+	"strings"
+	"time"
+)
+
+// conflictTimeoutIntervals is the number of report intervals after which an
+// entry of the conflicting address list expires, RFC 3550 Section 8.2.
+const conflictTimeoutIntervals = 10
+
+// CollisionKind tells apart the SSRC conflicts found by a CollisionDetector.
+type CollisionKind int
+
+const (
+	// ThirdPartyCollision is raised when two other participants use the
+	// same SSRC, which is seen as packets of one SSRC arriving from two
+	// transport addresses with different or unknown CNAMEs.
+	ThirdPartyCollision CollisionKind = iota
+	// ThirdPartyLoop is raised when the packets of another participant
+	// arrive from a second transport address with the same CNAME.
+	ThirdPartyLoop
+	// LocalCollision is raised when another participant uses our SSRC.
+	LocalCollision
+	// LocalLoop is raised when our own packets are looped back to us.
+	LocalLoop
+)
+
+func (k CollisionKind) String() string {
+	switch k {
+	case ThirdPartyCollision:
+		return "third-party collision"
+	case ThirdPartyLoop:
+		return "third-party loop"
+	case LocalCollision:
+		return "local collision"
+	case LocalLoop:
+		return "local loop"
+	}
+
+	return "invalid collision kind"
+}
+
+// CollisionAction is a set of actions to take on a Collision.
+type CollisionAction int
+
+const (
+	// CollisionActionIgnore means that the packets carrying the SSRC must
+	// not be processed.
+	CollisionActionIgnore CollisionAction = 1 << iota
+	// CollisionActionSendGoodbye means that a Goodbye must be sent for the
+	// old local SSRC.
+	CollisionActionSendGoodbye
+	// CollisionActionNewSSRC means that a new local SSRC must be chosen and
+	// set with SetLocalSSRC.
+	CollisionActionNewSSRC
+)
+
+func (a CollisionAction) String() string {
+	var actions []string
+	if a&CollisionActionIgnore != 0 {
+		actions = append(actions, "ignore")
+	}
+	if a&CollisionActionSendGoodbye != 0 {
+		actions = append(actions, "send goodbye")
+	}
+	if a&CollisionActionNewSSRC != 0 {
+		actions = append(actions, "new ssrc")
+	}
+	if len(actions) == 0 {
+		return "none"
+	}
+
+	return strings.Join(actions, ", ")
+}
+
+// Collision is an SSRC collision or loop detected by a CollisionDetector.
+type Collision struct {
+	Kind CollisionKind
+	// SSRC that collided.
+	SSRC uint32
+	// CNAME announced for the SSRC by the offending packet, if any.
+	CNAME string
+	// Source transport address of the offending packet.
+	Address net.Addr
+	Action  CollisionAction
+}
+
+type collisionSource struct {
+	cname     string
+	rtpAddr   string
+	rtcpAddr  string
+	lastHeard time.Time
+}
+
+// CollisionDetector detects SSRC collisions and forwarding loops following
+// the algorithm of RFC 3550 Section 8.2. It correlates the SSRC, the CNAME
+// and the source transport address of the RTP and RTCP packets received,
+// and tells collisions between other participants and loops, which are only
+// reported, from collisions with our own SSRC, after which we must send a
+// Goodbye and choose a new SSRC.
+//
+// RTP and RTCP source addresses are tracked separately. Addresses are
+// compared by their network and string representation.
+type CollisionDetector struct {
+	localSSRC  uint32
+	localCNAME string
+	sent       bool
+	sources    map[uint32]*collisionSource
+	conflicts  map[string]time.Time
+}
+
+// NewCollisionDetector creates a CollisionDetector for a participant using
+// the given SSRC and CNAME.
+func NewCollisionDetector(localSSRC uint32, localCNAME string) *CollisionDetector {
+	return &CollisionDetector{
+		localSSRC:  localSSRC,
+		localCNAME: localCNAME,
+		sources:    map[uint32]*collisionSource{},
+		conflicts:  map[string]time.Time{},
+	}
+}
+
+// LocalSSRC returns our own SSRC.
+func (d *CollisionDetector) LocalSSRC() uint32 {
+	return d.localSSRC
+}
+
+// SetLocalSSRC changes our own SSRC, typically after a LocalCollision.
+func (d *CollisionDetector) SetLocalSSRC(ssrc uint32) {
+	d.localSSRC = ssrc
+	d.sent = false
+}
+
+// PacketSent records that an RTP or RTCP packet was sent with our SSRC. A
+// participant that has not sent any packet does not need to send a Goodbye
+// when changing its SSRC.
+func (d *CollisionDetector) PacketSent() {
+	d.sent = true
+}
+
+// InUse reports whether ssrc is our own SSRC or used by another participant,
+// and should not be chosen as a new SSRC.
+func (d *CollisionDetector) InUse(ssrc uint32) bool {
+	_, ok := d.sources[ssrc]
+
+	return ok || ssrc == d.localSSRC
+}
+
+// ReceiveRTP checks an RTP packet of ssrc received from address from at
+// time now.
+func (d *CollisionDetector) ReceiveRTP(ssrc uint32, from net.Addr, now time.Time) (Collision, bool) {
+	return d.check(ssrc, "", from, false, now)
+}
+
+// Receive checks the RTCP packets received from address from at time now, as
+// returned by Unmarshal. The sources of SenderReports, ReceiverReports,
+// SourceDescriptions and Goodbyes are checked, other packets are ignored.
+// It returns at most one Collision per SSRC.
+func (d *CollisionDetector) Receive(pkts []Packet, from net.Addr, now time.Time) []Collision {
+	var ssrcs []uint32
+	var goodbyes []uint32
+	cnames := map[uint32]string{}
+	for _, pkt := range flattenPackets(pkts) {
+		switch p := pkt.(type) {
+		case *SenderReport:
+			ssrcs = append(ssrcs, p.SSRC)
+		case *ReceiverReport:
+			ssrcs = append(ssrcs, p.SSRC)
+		case *SourceDescription:
+			for _, chunk := range p.Chunks {
+				ssrcs = append(ssrcs, chunk.Source)
+				for _, item := range chunk.Items {
+					if item.Type == SDESCNAME {
+						cnames[chunk.Source] = item.Text
+					}
+				}
+			}
+		case *Goodbye:
+			ssrcs = append(ssrcs, p.Sources...)
+			goodbyes = append(goodbyes, p.Sources...)
+		}
+	}
+
+	// The CNAME usually follows the report in a compound packet, so all
+	// packets are looked at before checking any SSRC.
+	var collisions []Collision
+	checked := map[uint32]bool{}
+	for _, ssrc := range ssrcs {
+		if checked[ssrc] {
+			continue
+		}
+		checked[ssrc] = true
+		if c, ok := d.check(ssrc, cnames[ssrc], from, true, now); ok {
+			collisions = append(collisions, c)
+		}
+	}
+
+	for _, ssrc := range goodbyes {
+		if s, ok := d.sources[ssrc]; ok && s.rtcpAddr == addressKey(from) {
+			delete(d.sources, ssrc)
+		}
+	}
+
+	return collisions
+}
+
+// Timeout removes at time now the sources that have not been heard from in
+// five report intervals, after which their SSRC may be used from another
+// address, and the conflicting addresses that have not been seen in ten
+// report intervals. td is the deterministic report interval, see
+// IntervalCalculator.
+func (d *CollisionDetector) Timeout(now time.Time, td time.Duration) {
+	for ssrc, s := range d.sources {
+		if now.Sub(s.lastHeard) > memberTimeoutIntervals*td {
+			delete(d.sources, ssrc)
+		}
+	}
+	for addr, seen := range d.conflicts {
+		if now.Sub(seen) > conflictTimeoutIntervals*td {
+			delete(d.conflicts, addr)
+		}
+	}
+}
+
+func (d *CollisionDetector) check(ssrc uint32, cname string, from net.Addr, rtcp bool, now time.Time) (Collision, bool) {
+	addr := addressKey(from)
+	collision := Collision{SSRC: ssrc, CNAME: cname, Address: from}
+
+	if ssrc == d.localSSRC {
+		_, conflicting := d.conflicts[addr]
+		d.conflicts[addr] = now
+		if conflicting || (cname != "" && cname == d.localCNAME) {
+			collision.Kind = LocalLoop
+			collision.Action = CollisionActionIgnore
+
+			return collision, true
+		}
+
+		// The other participant keeps the SSRC.
+		d.sources[ssrc] = d.newSource(cname, addr, rtcp, now)
+		collision.Kind = LocalCollision
+		collision.Action = CollisionActionNewSSRC
+		if d.sent {
+			collision.Action |= CollisionActionSendGoodbye
+		}
+
+		return collision, true
+	}
+
+	s, ok := d.sources[ssrc]
+	if !ok {
+		d.sources[ssrc] = d.newSource(cname, addr, rtcp, now)
+
+		return Collision{}, false
+	}
+
+	known := &s.rtpAddr
+	if rtcp {
+		known = &s.rtcpAddr
+	}
+	if *known == "" {
+		*known = addr
+	}
+	if *known == addr {
+		s.lastHeard = now
+		if cname != "" {
+			s.cname = cname
+		}
+
+		return Collision{}, false
+	}
+
+	collision.Kind = ThirdPartyCollision
+	if cname != "" && cname == s.cname {
+		collision.Kind = ThirdPartyLoop
+	}
+	collision.Action = CollisionActionIgnore
+
+	return collision, true
+}
+
+func (d *CollisionDetector) newSource(cname, addr string, rtcp bool, now time.Time) *collisionSource {
+	s := &collisionSource{cname: cname, lastHeard: now}
+	if rtcp {
+		s.rtcpAddr = addr
+	} else {
+		s.rtpAddr = addr
+	}
+
+	return s
+}
+
+// flattenPackets returns pkts with the packets of compound packets inlined.
+func flattenPackets(pkts []Packet) []Packet {
+	var out []Packet
+	for _, pkt := range pkts {
+		if compound, ok := pkt.(*CompoundPacket); ok {
+			out = append(out, flattenPackets(*compound)...)
+
+			continue
+		}
+		out = append(out, pkt)
+	}
+
+	return out
+}
+
+func addressKey(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+
+	return addr.Network() + "/" + addr.String()
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollisionDetectorThirdParty(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	alice := &net.UDPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 5005}
+	aliceRTP := &net.UDPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 5004}
+	bob := &net.UDPAddr{IP: net.IPv4(192, 0, 2, 2), Port: 5005}
+	translator := &net.UDPAddr{IP: net.IPv4(198, 51, 100, 1), Port: 5005}
+	detector := NewCollisionDetector(0x1, "me@example.com")
+
+	assert.Empty(t, detector.Receive([]Packet{&CompoundPacket{
+		&ReceiverReport{SSRC: 0xA},
+		NewCNAMESourceDescription(0xA, "alice@example.com"),
+	}}, alice, now))
+	assert.Empty(t, detector.Receive([]Packet{&SenderReport{SSRC: 0xA}}, alice, now))
+
+	// RTP is sent from another port than RTCP.
+	_, ok := detector.ReceiveRTP(0xA, aliceRTP, now)
+	assert.False(t, ok)
+	_, ok = detector.ReceiveRTP(0xA, aliceRTP, now)
+	assert.False(t, ok)
+
+	// Bob picked the same SSRC as Alice.
+	assert.Equal(t, []Collision{{
+		Kind:    ThirdPartyCollision,
+		SSRC:    0xA,
+		CNAME:   "bob@example.com",
+		Address: bob,
+		Action:  CollisionActionIgnore,
+	}}, detector.Receive([]Packet{&CompoundPacket{
+		&ReceiverReport{SSRC: 0xA},
+		NewCNAMESourceDescription(0xA, "bob@example.com"),
+	}}, bob, now))
+
+	collision, ok := detector.ReceiveRTP(0xA, bob, now)
+	assert.True(t, ok)
+	assert.Equal(t, ThirdPartyCollision, collision.Kind)
+
+	// Alice's packets are looped back by a translator.
+	assert.Equal(t, []Collision{{
+		Kind:    ThirdPartyLoop,
+		SSRC:    0xA,
+		CNAME:   "alice@example.com",
+		Address: translator,
+		Action:  CollisionActionIgnore,
+	}}, detector.Receive([]Packet{&CompoundPacket{
+		&SenderReport{SSRC: 0xA},
+		NewCNAMESourceDescription(0xA, "alice@example.com"),
+	}}, translator, now))
+
+	// Once Alice timed out, the SSRC may be used from another address.
+	td := 5 * time.Second
+	assert.Empty(t, detector.Receive([]Packet{&ReceiverReport{SSRC: 0xA}}, alice, now.Add(20*time.Second)))
+	detector.Timeout(now.Add(40*time.Second), td)
+	assert.True(t, detector.InUse(0xA))
+	detector.Timeout(now.Add(46*time.Second), td)
+	assert.False(t, detector.InUse(0xA))
+	assert.Empty(t, detector.Receive([]Packet{&ReceiverReport{SSRC: 0xA}}, bob, now.Add(46*time.Second)))
+}
+
+func TestCollisionDetectorGoodbye(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	alice := &net.UDPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 5005}
+	bob := &net.UDPAddr{IP: net.IPv4(192, 0, 2, 2), Port: 5005}
+	detector := NewCollisionDetector(0x1, "me@example.com")
+
+	assert.Empty(t, detector.Receive([]Packet{&ReceiverReport{SSRC: 0xA}}, alice, now))
+
+	// A Goodbye from another address is a collision and does not remove
+	// the source.
+	collisions := detector.Receive([]Packet{&Goodbye{Sources: []uint32{0xA}}}, bob, now)
+	assert.Len(t, collisions, 1)
+	assert.True(t, detector.InUse(0xA))
+
+	assert.Empty(t, detector.Receive([]Packet{&CompoundPacket{
+		&ReceiverReport{SSRC: 0xA},
+		&Goodbye{Sources: []uint32{0xA}},
+	}}, alice, now))
+	assert.False(t, detector.InUse(0xA))
+}
+
+func TestCollisionDetectorLocal(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	bob := &net.UDPAddr{IP: net.IPv4(192, 0, 2, 2), Port: 5005}
+	loop := &net.UDPAddr{IP: net.IPv4(198, 51, 100, 1), Port: 5005}
+	detector := NewCollisionDetector(0x1, "me@example.com")
+	detector.PacketSent()
+
+	// Bob uses our SSRC: we must send a Goodbye and pick a new SSRC,
+	// while Bob's packet is processed normally.
+	assert.Equal(t, []Collision{{
+		Kind:    LocalCollision,
+		SSRC:    0x1,
+		CNAME:   "bob@example.com",
+		Address: bob,
+		Action:  CollisionActionSendGoodbye | CollisionActionNewSSRC,
+	}}, detector.Receive([]Packet{&CompoundPacket{
+		&SenderReport{SSRC: 0x1},
+		NewCNAMESourceDescription(0x1, "bob@example.com"),
+	}}, bob, now))
+
+	detector.SetLocalSSRC(0x2)
+	assert.Equal(t, uint32(0x2), detector.LocalSSRC())
+	assert.True(t, detector.InUse(0x1))
+	assert.Empty(t, detector.Receive([]Packet{&SenderReport{SSRC: 0x1}}, bob, now))
+
+	// Without sending anything with the new SSRC, no Goodbye is needed.
+	collision, ok := detector.ReceiveRTP(0x2, loop, now)
+	assert.True(t, ok)
+	assert.Equal(t, LocalCollision, collision.Kind)
+	assert.Equal(t, CollisionActionNewSSRC, collision.Action)
+
+	// The colliding address turns out to loop our packets back, so the
+	// new SSRC arriving from it is a loop.
+	detector.SetLocalSSRC(0x3)
+	detector.PacketSent()
+	collision, ok = detector.ReceiveRTP(0x3, loop, now)
+	assert.True(t, ok)
+	assert.Equal(t, Collision{Kind: LocalLoop, SSRC: 0x3, Address: loop, Action: CollisionActionIgnore}, collision)
+
+	// Our own CNAME identifies a loop from an address not seen before.
+	other := &net.UDPAddr{IP: net.IPv4(203, 0, 113, 1), Port: 5005}
+	assert.Equal(t, []Collision{{
+		Kind:    LocalLoop,
+		SSRC:    0x3,
+		CNAME:   "me@example.com",
+		Address: other,
+		Action:  CollisionActionIgnore,
+	}}, detector.Receive([]Packet{&CompoundPacket{
+		&ReceiverReport{SSRC: 0x3},
+		NewCNAMESourceDescription(0x3, "me@example.com"),
+	}}, other, now))
+
+	// Conflicting addresses expire after ten report intervals.
+	td := 5 * time.Second
+	detector.Timeout(now.Add(51*time.Second), td)
+	collision, ok = detector.ReceiveRTP(0x3, loop, now.Add(51*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, LocalCollision, collision.Kind)
+}
+
+func TestCollisionStrings(t *testing.T) {
+	assert.Equal(t, "third-party collision", ThirdPartyCollision.String())
+	assert.Equal(t, "local loop", LocalLoop.String())
+	assert.Equal(t, "invalid collision kind", CollisionKind(42).String())
+	assert.Equal(t, "none", CollisionAction(0).String())
+	assert.Equal(t, "ignore", CollisionActionIgnore.String())
+	assert.Equal(t, "send goodbye, new ssrc", (CollisionActionSendGoodbye | CollisionActionNewSSRC).String())
+}
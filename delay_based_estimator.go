@@ -0,0 +1,602 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// BandwidthUsage is the state of the network path detected from the
+// variation of the one-way delay.
+type BandwidthUsage int
+
+// BandwidthUsage values.
+const (
+	BandwidthNormal BandwidthUsage = iota
+	BandwidthUnderusing
+	BandwidthOverusing
+)
+
+func (u BandwidthUsage) String() string {
+	switch u {
+	case BandwidthNormal:
+		return "normal"
+	case BandwidthUnderusing:
+		return "underusing"
+	case BandwidthOverusing:
+		return "overusing"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	defaultDelayBasedMinBitrate = 30000
+	defaultDelayBasedMaxBitrate = 10000000
+	defaultDelayBasedRTT        = 200 * time.Millisecond
+)
+
+// DelayBasedEstimator is the delay-based controller of Google Congestion
+// Control, draft-ietf-rmcat-gcc-02 Section 5, as implemented by libwebrtc.
+//
+// Packets are grouped into bursts sent within 5ms, and the variation of
+// the delay between consecutive groups is smoothed by a trendline filter.
+// The overuse detector compares the trend with an adaptive threshold, and
+// an AIMD controller adapts the target bitrate to the detected state,
+// backing off to 85% of the acknowledged bitrate on overuse.
+//
+// All times come from the PacketFeedback and the clock passed to Update, so
+// the estimator is deterministic.
+type DelayBasedEstimator struct {
+	// MinBitrate and MaxBitrate bound the target bitrate, in bits per second.
+	MinBitrate, MaxBitrate uint64
+	// RTT is the round-trip time of the path, used to pace the decreases and
+	// the additive increases.
+	RTT time.Duration
+
+	interArrival interArrival
+	trendline    trendlineEstimator
+	throughput   throughputEstimator
+	rate         aimdRateController
+}
+
+// NewDelayBasedEstimator creates a DelayBasedEstimator starting at
+// initialBitrate bits per second.
+func NewDelayBasedEstimator(initialBitrate uint64) *DelayBasedEstimator {
+	return &DelayBasedEstimator{
+		MinBitrate: defaultDelayBasedMinBitrate,
+		MaxBitrate: defaultDelayBasedMaxBitrate,
+		RTT:        defaultDelayBasedRTT,
+		trendline:  newTrendlineEstimator(),
+		rate:       newAIMDRateController(float64(initialBitrate)),
+	}
+}
+
+// Update processes the feedback received at time now and returns the
+// target bitrate, in bits per second. Packets not received or without an
+// arrival time are ignored.
+func (e *DelayBasedEstimator) Update(feedback []PacketFeedback, now time.Time) uint64 {
+	received := make([]PacketFeedback, 0, len(feedback))
+	for _, packet := range feedback {
+		if packet.Received && !packet.Arrival.IsZero() {
+			received = append(received, packet)
+		}
+	}
+	if len(received) == 0 {
+		return e.TargetBitrate()
+	}
+	slices.SortStableFunc(received, func(a, b PacketFeedback) int {
+		return a.Arrival.Compare(b.Arrival)
+	})
+
+	for _, packet := range received {
+		e.throughput.add(packet.Arrival, packet.Size)
+		sendDelta, arrivalDelta, ok := e.interArrival.computeDeltas(packet.SendTime, packet.Arrival, now, packet.Size)
+		if ok {
+			e.trendline.update(durationMilliseconds(arrivalDelta), durationMilliseconds(sendDelta), packet.Arrival)
+		}
+	}
+
+	e.rate.rtt = e.RTT
+	throughput, ok := e.throughput.rate()
+	if !ok {
+		throughput = e.rate.latestThroughput
+	}
+	usage := e.trendline.hypothesis
+	if usage != BandwidthOverusing || e.rate.timeToReduceFurther(now, throughput) {
+		e.rate.update(usage, throughput, now)
+	}
+	e.rate.bitrate = min(max(e.rate.bitrate, float64(e.MinBitrate)), float64(e.MaxBitrate))
+
+	return e.TargetBitrate()
+}
+
+// TargetBitrate returns the current target bitrate, in bits per second.
+func (e *DelayBasedEstimator) TargetBitrate() uint64 {
+	return uint64(e.rate.bitrate)
+}
+
+// Usage returns the state of the path detected by the last Update.
+func (e *DelayBasedEstimator) Usage() BandwidthUsage {
+	return e.trendline.hypothesis
+}
+
+func durationMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// Grouping of the packets into bursts, draft-ietf-rmcat-gcc-02 Section 5.2.
+const (
+	sendTimeGroupLength        = 5 * time.Millisecond
+	burstDeltaThreshold        = 5 * time.Millisecond
+	maxBurstDuration           = 100 * time.Millisecond
+	arrivalTimeOffsetThreshold = 3 * time.Second
+	reorderedResetThreshold    = 3
+)
+
+type packetGroup struct {
+	size           int
+	firstSendTime  time.Time
+	sendTime       time.Time
+	firstArrival   time.Time
+	completeTime   time.Time
+	lastSystemTime time.Time
+}
+
+func (g *packetGroup) isFirstPacket() bool {
+	return g.completeTime.IsZero()
+}
+
+// interArrival computes the send and arrival time deltas between
+// consecutive groups of packets.
+type interArrival struct {
+	current, previous packetGroup
+	reordered         int
+}
+
+// computeDeltas adds a packet and returns the deltas between the two last
+// groups when the packet starts a new group.
+func (a *interArrival) computeDeltas(
+	sendTime, arrival, now time.Time,
+	size int,
+) (sendDelta, arrivalDelta time.Duration, ok bool) {
+	switch {
+	case a.current.isFirstPacket():
+		a.current.sendTime = sendTime
+		a.current.firstSendTime = sendTime
+		a.current.firstArrival = arrival
+	case a.current.firstSendTime.After(sendTime):
+		// Reordered packet.
+		return 0, 0, false
+	case a.newGroup(sendTime, arrival):
+		if !a.previous.isFirstPacket() {
+			sendDelta = a.current.sendTime.Sub(a.previous.sendTime)
+			arrivalDelta = a.current.completeTime.Sub(a.previous.completeTime)
+			systemDelta := a.current.lastSystemTime.Sub(a.previous.lastSystemTime)
+			if arrivalDelta-systemDelta >= arrivalTimeOffsetThreshold {
+				// The clock of the receiver jumped.
+				*a = interArrival{}
+
+				return 0, 0, false
+			}
+			if arrivalDelta < 0 {
+				a.reordered++
+				if a.reordered >= reorderedResetThreshold {
+					*a = interArrival{}
+				}
+
+				return 0, 0, false
+			}
+			a.reordered = 0
+			ok = true
+		}
+		a.previous = a.current
+		a.current = packetGroup{firstSendTime: sendTime, sendTime: sendTime, firstArrival: arrival}
+	default:
+		if sendTime.After(a.current.sendTime) {
+			a.current.sendTime = sendTime
+		}
+	}
+
+	a.current.size += size
+	a.current.completeTime = arrival
+	a.current.lastSystemTime = now
+
+	return sendDelta, arrivalDelta, ok
+}
+
+func (a *interArrival) newGroup(sendTime, arrival time.Time) bool {
+	if a.belongsToBurst(sendTime, arrival) {
+		return false
+	}
+
+	return sendTime.Sub(a.current.firstSendTime) > sendTimeGroupLength
+}
+
+// belongsToBurst reports whether a packet arrived in a burst with the
+// current group, having been queued behind it.
+func (a *interArrival) belongsToBurst(sendTime, arrival time.Time) bool {
+	arrivalDelta := arrival.Sub(a.current.completeTime)
+	sendDelta := sendTime.Sub(a.current.sendTime)
+	if sendDelta == 0 {
+		return true
+	}
+
+	return arrivalDelta-sendDelta < 0 &&
+		arrivalDelta <= burstDeltaThreshold &&
+		arrival.Sub(a.current.firstArrival) < maxBurstDuration
+}
+
+// Trendline filter and overuse detector, draft-ietf-rmcat-gcc-02 Sections
+// 5.3 and 5.4, with the parameters of libwebrtc.
+const (
+	trendlineWindowSize    = 20
+	trendlineSmoothingCoef = 0.9
+	trendlineThresholdGain = 4.0
+	trendlineMinNumDeltas  = 60
+	trendlineMaxNumDeltas  = 1000
+	overusingTimeThreshold = 10.0
+	maxAdaptOffset         = 15.0
+	thresholdGainUp        = 0.0087
+	thresholdGainDown      = 0.039
+	initialThreshold       = 12.5
+	minThreshold           = 6.0
+	maxThreshold           = 600.0
+	maxThresholdTimeDelta  = 100.0
+)
+
+type delaySample struct {
+	arrival, smoothedDelay float64
+}
+
+type trendlineEstimator struct {
+	numDeltas        int
+	firstArrival     time.Time
+	accumulatedDelay float64
+	smoothedDelay    float64
+	history          []delaySample
+	prevTrend        float64
+	threshold        float64
+	timeOverUsing    float64
+	overuseCounter   int
+	lastUpdate       time.Time
+	hypothesis       BandwidthUsage
+}
+
+func newTrendlineEstimator() trendlineEstimator {
+	return trendlineEstimator{threshold: initialThreshold, timeOverUsing: -1}
+}
+
+// update adds the deltas between two groups, in milliseconds, the later
+// group completing at arrival.
+func (t *trendlineEstimator) update(arrivalDelta, sendDelta float64, arrival time.Time) {
+	t.numDeltas = min(t.numDeltas+1, trendlineMaxNumDeltas)
+	if t.firstArrival.IsZero() {
+		t.firstArrival = arrival
+	}
+
+	t.accumulatedDelay += arrivalDelta - sendDelta
+	t.smoothedDelay = trendlineSmoothingCoef*t.smoothedDelay + (1-trendlineSmoothingCoef)*t.accumulatedDelay
+	t.history = append(t.history, delaySample{
+		arrival:       durationMilliseconds(arrival.Sub(t.firstArrival)),
+		smoothedDelay: t.smoothedDelay,
+	})
+	if len(t.history) > trendlineWindowSize {
+		t.history = t.history[1:]
+	}
+
+	// The trend estimates (send rate - capacity) / capacity: it is positive
+	// while queues build up, and negative while they drain.
+	trend := t.prevTrend
+	if len(t.history) == trendlineWindowSize {
+		if slope, ok := linearFitSlope(t.history); ok {
+			trend = slope
+		}
+	}
+	t.detect(trend, sendDelta, arrival)
+}
+
+func linearFitSlope(samples []delaySample) (float64, bool) {
+	var sumX, sumY float64
+	for _, sample := range samples {
+		sumX += sample.arrival
+		sumY += sample.smoothedDelay
+	}
+	avgX := sumX / float64(len(samples))
+	avgY := sumY / float64(len(samples))
+
+	var numerator, denominator float64
+	for _, sample := range samples {
+		numerator += (sample.arrival - avgX) * (sample.smoothedDelay - avgY)
+		denominator += (sample.arrival - avgX) * (sample.arrival - avgX)
+	}
+	if denominator == 0 {
+		return 0, false
+	}
+
+	return numerator / denominator, true
+}
+
+func (t *trendlineEstimator) detect(trend, sendDelta float64, now time.Time) {
+	if t.numDeltas < 2 {
+		t.hypothesis = BandwidthNormal
+
+		return
+	}
+
+	modifiedTrend := float64(min(t.numDeltas, trendlineMinNumDeltas)) * trend * trendlineThresholdGain
+	switch {
+	case modifiedTrend > t.threshold:
+		if t.timeOverUsing == -1 {
+			// Assume the path has been overused for half of the time since
+			// the previous sample.
+			t.timeOverUsing = sendDelta / 2
+		} else {
+			t.timeOverUsing += sendDelta
+		}
+		t.overuseCounter++
+		if t.timeOverUsing > overusingTimeThreshold && t.overuseCounter > 1 && trend >= t.prevTrend {
+			t.timeOverUsing = 0
+			t.overuseCounter = 0
+			t.hypothesis = BandwidthOverusing
+		}
+	case modifiedTrend < -t.threshold:
+		t.timeOverUsing = -1
+		t.overuseCounter = 0
+		t.hypothesis = BandwidthUnderusing
+	default:
+		t.timeOverUsing = -1
+		t.overuseCounter = 0
+		t.hypothesis = BandwidthNormal
+	}
+
+	t.prevTrend = trend
+	t.updateThreshold(modifiedTrend, now)
+}
+
+// updateThreshold adapts the threshold to the trend, so that the detector
+// does not starve against concurrent TCP flows, draft-ietf-rmcat-gcc-02
+// Section 5.4.
+func (t *trendlineEstimator) updateThreshold(modifiedTrend float64, now time.Time) {
+	if t.lastUpdate.IsZero() {
+		t.lastUpdate = now
+	}
+	absTrend := math.Abs(modifiedTrend)
+	if absTrend > t.threshold+maxAdaptOffset {
+		// Do not adapt to the latency spikes of a sudden capacity drop.
+		t.lastUpdate = now
+
+		return
+	}
+
+	gain := thresholdGainUp
+	if absTrend < t.threshold {
+		gain = thresholdGainDown
+	}
+	timeDelta := min(durationMilliseconds(now.Sub(t.lastUpdate)), maxThresholdTimeDelta)
+	t.threshold += gain * (absTrend - t.threshold) * timeDelta
+	t.threshold = min(max(t.threshold, minThreshold), maxThreshold)
+	t.lastUpdate = now
+}
+
+const throughputWindow = 500 * time.Millisecond
+
+type throughputSample struct {
+	arrival time.Time
+	size    int
+}
+
+// throughputEstimator measures the acknowledged bitrate over a sliding
+// window of arrival times.
+type throughputEstimator struct {
+	first   time.Time
+	samples []throughputSample
+	bytes   int
+}
+
+func (t *throughputEstimator) add(arrival time.Time, size int) {
+	if t.first.IsZero() {
+		t.first = arrival
+	}
+	t.samples = append(t.samples, throughputSample{arrival: arrival, size: size})
+	t.bytes += size
+	for len(t.samples) > 0 && arrival.Sub(t.samples[0].arrival) >= throughputWindow {
+		t.bytes -= t.samples[0].size
+		t.samples = t.samples[1:]
+	}
+}
+
+// rate returns the acknowledged bitrate, once a full window has been
+// observed.
+func (t *throughputEstimator) rate() (float64, bool) {
+	if len(t.samples) == 0 || t.samples[len(t.samples)-1].arrival.Sub(t.first) < throughputWindow {
+		return 0, false
+	}
+
+	return float64(t.bytes*8) / throughputWindow.Seconds(), true
+}
+
+// AIMD rate controller, draft-ietf-rmcat-gcc-02 Section 5.5, with the
+// parameters of libwebrtc.
+const (
+	aimdBeta                = 0.85
+	aimdMultiplicativeAlpha = 1.08
+	aimdMinMultiplicative   = 1000.0
+	aimdMinAdditive         = 4000.0
+	aimdDecreaseMargin      = 5000.0
+	aimdThroughputLimitGain = 1.5
+	aimdThroughputLimitBase = 10000.0
+	aimdPacketSize          = 1200 * 8
+	aimdFrameRate           = 30
+	aimdDetectorDelay       = 100 * time.Millisecond
+	minReductionInterval    = 10 * time.Millisecond
+	maxReductionInterval    = 200 * time.Millisecond
+)
+
+type rateControlState int
+
+const (
+	rateControlHold rateControlState = iota
+	rateControlIncrease
+	rateControlDecrease
+)
+
+type aimdRateController struct {
+	bitrate          float64
+	latestThroughput float64
+	rtt              time.Duration
+	state            rateControlState
+	lastChange       time.Time
+	linkCapacity     linkCapacityEstimator
+}
+
+func newAIMDRateController(initialBitrate float64) aimdRateController {
+	return aimdRateController{
+		bitrate:          initialBitrate,
+		latestThroughput: initialBitrate,
+		rtt:              defaultDelayBasedRTT,
+		linkCapacity:     newLinkCapacityEstimator(),
+	}
+}
+
+// timeToReduceFurther reports whether the bitrate may be decreased again,
+// at most once per RTT unless the throughput collapsed.
+func (c *aimdRateController) timeToReduceFurther(now time.Time, throughput float64) bool {
+	interval := min(max(c.rtt, minReductionInterval), maxReductionInterval)
+	if now.Sub(c.lastChange) >= interval {
+		return true
+	}
+
+	return throughput < c.bitrate/2
+}
+
+func (c *aimdRateController) update(usage BandwidthUsage, throughput float64, now time.Time) {
+	c.latestThroughput = throughput
+
+	switch usage {
+	case BandwidthNormal:
+		if c.state == rateControlHold {
+			c.lastChange = now
+			c.state = rateControlIncrease
+		}
+	case BandwidthOverusing:
+		c.state = rateControlDecrease
+	case BandwidthUnderusing:
+		c.state = rateControlHold
+	}
+
+	switch c.state {
+	case rateControlHold:
+	case rateControlIncrease:
+		if throughput > c.linkCapacity.upperBound() {
+			c.linkCapacity.reset()
+		}
+		limit := aimdThroughputLimitGain*throughput + aimdThroughputLimitBase
+		if c.bitrate < limit {
+			var increase float64
+			if c.linkCapacity.hasEstimate {
+				// Close to the link capacity, probe for more slowly.
+				increase = c.additiveIncrease(now)
+			} else {
+				increase = c.multiplicativeIncrease(now)
+			}
+			c.bitrate = min(c.bitrate+increase, limit)
+		}
+		c.lastChange = now
+	case rateControlDecrease:
+		// Drop slightly below the throughput to drain the queues.
+		decreased := aimdBeta * throughput
+		if decreased > aimdDecreaseMargin {
+			decreased -= aimdDecreaseMargin
+		}
+		if decreased > c.bitrate && c.linkCapacity.hasEstimate {
+			decreased = aimdBeta * c.linkCapacity.estimate * 1000
+		}
+		if decreased < c.bitrate {
+			c.bitrate = decreased
+		}
+		if throughput < c.linkCapacity.lowerBound() {
+			c.linkCapacity.reset()
+		}
+		c.linkCapacity.onOveruse(throughput)
+		// Hold until the queues are drained.
+		c.state = rateControlHold
+		c.lastChange = now
+	}
+}
+
+func (c *aimdRateController) multiplicativeIncrease(now time.Time) float64 {
+	alpha := aimdMultiplicativeAlpha
+	if !c.lastChange.IsZero() {
+		alpha = math.Pow(alpha, min(now.Sub(c.lastChange), time.Second).Seconds())
+	}
+
+	return max(c.bitrate*(alpha-1), aimdMinMultiplicative)
+}
+
+// additiveIncrease increases the bitrate by about one packet per response
+// time of the detector.
+func (c *aimdRateController) additiveIncrease(now time.Time) float64 {
+	frameSize := c.bitrate / aimdFrameRate
+	packetSize := frameSize / math.Ceil(frameSize/aimdPacketSize)
+	responseTime := 2 * (c.rtt + aimdDetectorDelay)
+	rate := max(packetSize/responseTime.Seconds(), aimdMinAdditive)
+
+	return rate * now.Sub(c.lastChange).Seconds()
+}
+
+const (
+	linkCapacityAlpha        = 0.05
+	linkCapacityMinDeviation = 0.4
+	linkCapacityMaxDeviation = 2.5
+)
+
+// linkCapacityEstimator tracks the throughput at which overuse was
+// detected, in kbps.
+type linkCapacityEstimator struct {
+	hasEstimate bool
+	estimate    float64
+	deviation   float64
+}
+
+func newLinkCapacityEstimator() linkCapacityEstimator {
+	return linkCapacityEstimator{deviation: linkCapacityMinDeviation}
+}
+
+func (l *linkCapacityEstimator) reset() {
+	*l = newLinkCapacityEstimator()
+}
+
+func (l *linkCapacityEstimator) upperBound() float64 {
+	if !l.hasEstimate {
+		return math.Inf(1)
+	}
+
+	return (l.estimate + 3*math.Sqrt(l.deviation*l.estimate)) * 1000
+}
+
+func (l *linkCapacityEstimator) lowerBound() float64 {
+	if !l.hasEstimate {
+		return 0
+	}
+
+	return max(0, l.estimate-3*math.Sqrt(l.deviation*l.estimate)) * 1000
+}
+
+func (l *linkCapacityEstimator) onOveruse(throughput float64) {
+	sample := throughput / 1000
+	if l.hasEstimate {
+		l.estimate = (1-linkCapacityAlpha)*l.estimate + linkCapacityAlpha*sample
+	} else {
+		l.estimate = sample
+		l.hasEstimate = true
+	}
+
+	// The variance is normalized by the estimate.
+	err := l.estimate - sample
+	l.deviation = (1-linkCapacityAlpha)*l.deviation + linkCapacityAlpha*err*err/max(l.estimate, 1)
+	l.deviation = min(max(l.deviation, linkCapacityMinDeviation), linkCapacityMaxDeviation)
+}
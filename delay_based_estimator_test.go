@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// bottleneck is a link serving the packets in order at capacity bits per
+// second, with an unbounded queue.
+type bottleneck struct {
+	capacity      func(time.Time) float64
+	propagation   time.Duration
+	lastDeparture time.Time
+}
+
+func (b *bottleneck) transmit(sendTime time.Time, size int) time.Time {
+	start := sendTime
+	if b.lastDeparture.After(start) {
+		start = b.lastDeparture
+	}
+	b.lastDeparture = start.Add(time.Duration(float64(size*8) / b.capacity(start) * float64(time.Second)))
+
+	return b.lastDeparture.Add(b.propagation)
+}
+
+type delayBasedSimulation struct {
+	// Target bitrate at the end of each second.
+	targets []uint64
+	// Queueing delay of the packets sent during each second.
+	maxQueueDelay []time.Duration
+}
+
+// simulateDelayBased paces 1200 byte packets at the target bitrate of
+// estimator through link, feeding back the packets received every 50ms.
+func simulateDelayBased(estimator *DelayBasedEstimator, link *bottleneck, duration time.Duration) delayBasedSimulation {
+	const (
+		packetSize       = 1200
+		feedbackInterval = 50 * time.Millisecond
+	)
+	start := time.Unix(1700000000, 0)
+	now := start
+	nextSend := start
+	nextFeedback := start.Add(feedbackInterval)
+	var pending []PacketFeedback
+	var result delayBasedSimulation
+
+	for now.Before(start.Add(duration)) {
+		second := int(now.Sub(start) / time.Second)
+		if len(result.targets) <= second {
+			result.targets = append(result.targets, 0)
+			result.maxQueueDelay = append(result.maxQueueDelay, 0)
+		}
+
+		if !nextSend.After(nextFeedback) {
+			now = nextSend
+			arrival := link.transmit(now, packetSize)
+			pending = append(pending, PacketFeedback{SendTime: now, Size: packetSize, Received: true, Arrival: arrival})
+			result.maxQueueDelay[second] = max(result.maxQueueDelay[second], arrival.Sub(now)-link.propagation)
+			interval := float64(packetSize*8) / float64(estimator.TargetBitrate())
+			nextSend = now.Add(time.Duration(interval * float64(time.Second)))
+		} else {
+			now = nextFeedback
+			var feedback []PacketFeedback
+			for len(pending) > 0 && !pending[0].Arrival.After(now) {
+				feedback = append(feedback, pending[0])
+				pending = pending[1:]
+			}
+			result.targets[second] = estimator.Update(feedback, now.Add(link.propagation))
+			nextFeedback = now.Add(feedbackInterval)
+		}
+	}
+
+	return result
+}
+
+func constantCapacity(capacity float64) func(time.Time) float64 {
+	return func(time.Time) float64 { return capacity }
+}
+
+func TestDelayBasedEstimatorBottleneck(t *testing.T) {
+	estimator := NewDelayBasedEstimator(300000)
+	link := &bottleneck{capacity: constantCapacity(1000000), propagation: 20 * time.Millisecond}
+	result := simulateDelayBased(estimator, link, 60*time.Second)
+
+	// The estimate converges below the capacity, keeping the queue short.
+	for second := 20; second < 60; second++ {
+		assert.GreaterOrEqual(t, result.targets[second], uint64(800000), "second %d", second)
+		assert.LessOrEqual(t, result.targets[second], uint64(1100000), "second %d", second)
+	}
+	for second, delay := range result.maxQueueDelay {
+		assert.Less(t, delay, 100*time.Millisecond, "second %d", second)
+	}
+}
+
+func TestDelayBasedEstimatorCapacityDrop(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	estimator := NewDelayBasedEstimator(300000)
+	link := &bottleneck{capacity: func(at time.Time) float64 {
+		if at.Sub(start) < 30*time.Second {
+			return 2500000
+		}
+
+		return 800000
+	}, propagation: 20 * time.Millisecond}
+	result := simulateDelayBased(estimator, link, 60*time.Second)
+
+	assert.Greater(t, result.targets[29], uint64(2000000))
+	// The estimate backs off below the new capacity within a second, and the
+	// queue drains within a few seconds.
+	for second := 30; second < 60; second++ {
+		assert.GreaterOrEqual(t, result.targets[second], uint64(600000), "second %d", second)
+		assert.LessOrEqual(t, result.targets[second], uint64(850000), "second %d", second)
+	}
+	for second := 35; second < 60; second++ {
+		assert.Less(t, result.maxQueueDelay[second], 100*time.Millisecond, "second %d", second)
+	}
+}
+
+func TestDelayBasedEstimatorNoQueueing(t *testing.T) {
+	estimator := NewDelayBasedEstimator(300000)
+	estimator.MaxBitrate = 2000000
+	link := &bottleneck{capacity: constantCapacity(100000000), propagation: 20 * time.Millisecond}
+	result := simulateDelayBased(estimator, link, 40*time.Second)
+
+	// The estimate increases multiplicatively up to MaxBitrate.
+	for second := 1; second < 40; second++ {
+		assert.GreaterOrEqual(t, result.targets[second], result.targets[second-1], "second %d", second)
+	}
+	assert.Equal(t, uint64(2000000), result.targets[39])
+	assert.Equal(t, BandwidthNormal, estimator.Usage())
+}
+
+func TestDelayBasedEstimatorIgnoresLostPackets(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	estimator := NewDelayBasedEstimator(300000)
+
+	assert.Equal(t, uint64(300000), estimator.Update([]PacketFeedback{
+		{SendTime: now, Size: 1200},
+		{SendTime: now, Size: 1200, Received: true},
+	}, now))
+	assert.Equal(t, uint64(300000), estimator.Update(nil, now))
+}
+
+func TestTrendlineEstimator(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		delayDelta float64
+		usage      BandwidthUsage
+	}{
+		{"Constant", 0, BandwidthNormal},
+		{"Increasing", 2, BandwidthOverusing},
+		{"Decreasing", -2, BandwidthUnderusing},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			trendline := newTrendlineEstimator()
+			arrival := time.Unix(1700000000, 0)
+			for i := 0; i < 100; i++ {
+				arrival = arrival.Add(10 * time.Millisecond)
+				trendline.update(10+test.delayDelta, 10, arrival)
+			}
+			assert.Equal(t, test.usage, trendline.hypothesis)
+		})
+	}
+}
+
+func TestInterArrival(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	var arrival interArrival
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	// Packets sent within 5ms form a group.
+	_, _, ok := arrival.computeDeltas(at(0), at(100), at(100), 1200)
+	assert.False(t, ok)
+	_, _, ok = arrival.computeDeltas(at(3), at(104), at(104), 1200)
+	assert.False(t, ok)
+	_, _, ok = arrival.computeDeltas(at(10), at(112), at(112), 1200)
+	assert.False(t, ok)
+
+	// A packet queued behind the group belongs to its burst.
+	_, _, ok = arrival.computeDeltas(at(14), at(113), at(113), 1200)
+	assert.False(t, ok)
+
+	sendDelta, arrivalDelta, ok := arrival.computeDeltas(at(20), at(125), at(125), 1200)
+	assert.True(t, ok)
+	assert.Equal(t, 11*time.Millisecond, sendDelta)
+	assert.Equal(t, 9*time.Millisecond, arrivalDelta)
+
+	// Reordered packets are ignored.
+	_, _, ok = arrival.computeDeltas(at(15), at(126), at(126), 1200)
+	assert.False(t, ok)
+}
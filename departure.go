@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import "time"
+
+// Departure implements leaving an RTP session gracefully, RFC 3550
+// Section 6.3.7. It builds the final compound packet, which carries an
+// empty ReceiverReport, our CNAME and the Goodbye, and uses the
+// IntervalCalculator of the session for BYE reconsideration, so that large
+// sessions are not flooded when many members leave at once.
+//
+// A participant that never sent an RTP or RTCP packet must not send a
+// Goodbye and should not use a Departure.
+type Departure struct {
+	// SSRCs leaving the session. The reports are sent by the first one.
+	SSRCs []uint32
+	// CNAME of the leaving participant.
+	CNAME string
+
+	calc      *IntervalCalculator
+	packet    CompoundPacket
+	immediate bool
+	leftAt    time.Time
+	done      bool
+}
+
+// NewDeparture creates a Departure for the sources ssrcs of a participant
+// with the given CNAME, timed by calc.
+func NewDeparture(calc *IntervalCalculator, cname string, ssrcs ...uint32) *Departure {
+	return &Departure{SSRCs: ssrcs, CNAME: cname, calc: calc}
+}
+
+// Leave starts leaving the session at time now with the given reason, which
+// may be empty. It returns the time the Goodbye is scheduled for, after
+// which Poll should be called. Sources beyond the 31 a Goodbye can carry
+// are split across several Goodbye packets ending the compound packet.
+func (d *Departure) Leave(reason string, now time.Time) (time.Time, error) {
+	if len(d.SSRCs) == 0 {
+		return time.Time{}, errNoSources
+	}
+	if len(reason) > sdesMaxOctetCount {
+		return time.Time{}, errReasonTooLong
+	}
+
+	packet := CompoundPacket{
+		&ReceiverReport{SSRC: d.SSRCs[0]},
+		NewCNAMESourceDescription(d.SSRCs[0], d.CNAME),
+	}
+	for i := 0; i < len(d.SSRCs); i += countMax {
+		sources := d.SSRCs[i:min(i+countMax, len(d.SSRCs))]
+		packet = append(packet, &Goodbye{Sources: sources, Reason: reason})
+	}
+	if err := packet.Validate(); err != nil {
+		return time.Time{}, err
+	}
+
+	d.packet = packet
+	d.done = false
+	d.leftAt = now
+	d.immediate = d.calc.Leave(packet.MarshalSize(), now)
+	if d.immediate {
+		return now, nil
+	}
+
+	return d.calc.NextTransmission(), nil
+}
+
+// Receive accounts for a compound packet of size octets received while
+// leaving, as returned by Unmarshal. Only Goodbye packets are counted.
+func (d *Departure) Receive(pkts []Packet, size int) {
+	goodbyes := 0
+	for _, pkt := range flattenPackets(pkts) {
+		if _, ok := pkt.(*Goodbye); ok {
+			goodbyes++
+		}
+	}
+	d.calc.GoodbyeReceived(size, goodbyes)
+}
+
+// NextTransmission returns the time the Goodbye is scheduled for.
+func (d *Departure) NextTransmission() time.Time {
+	if d.immediate {
+		return d.leftAt
+	}
+
+	return d.calc.NextTransmission()
+}
+
+// Poll returns the final compound packet if it is to be sent at time now.
+// It is returned once, and never before Leave was called.
+func (d *Departure) Poll(now time.Time) (CompoundPacket, bool) {
+	if d.packet == nil || d.done {
+		return nil, false
+	}
+	if !d.immediate && !d.calc.Reconsider(now) {
+		return nil, false
+	}
+
+	d.done = true
+
+	return d.packet, true
+}
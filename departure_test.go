@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDepartureSmallGroup(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	calc := NewIntervalCalculator(64000, now)
+	calc.SetMembers(10, 2, now)
+	departure := NewDeparture(calc, "me@example.com", 0x1)
+
+	_, ok := departure.Poll(now)
+	assert.False(t, ok, "nothing is sent before leaving")
+
+	sendAt, err := departure.Leave("bye", now)
+	assert.NoError(t, err)
+	assert.Equal(t, now, sendAt)
+	assert.Equal(t, now, departure.NextTransmission())
+	assert.False(t, calc.Leaving())
+
+	packet, ok := departure.Poll(now)
+	assert.True(t, ok)
+	assert.Equal(t, CompoundPacket{
+		&ReceiverReport{SSRC: 0x1},
+		NewCNAMESourceDescription(0x1, "me@example.com"),
+		&Goodbye{Sources: []uint32{0x1}, Reason: "bye"},
+	}, packet)
+
+	raw, err := packet.Marshal()
+	assert.NoError(t, err)
+	var decoded CompoundPacket
+	assert.NoError(t, decoded.Unmarshal(raw))
+	assert.Equal(t, packet[2], decoded[2])
+	cname, err := decoded.CNAME()
+	assert.NoError(t, err)
+	assert.Equal(t, "me@example.com", cname)
+
+	_, ok = departure.Poll(now)
+	assert.False(t, ok, "the packet is sent only once")
+}
+
+func TestDepartureErrors(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	calc := NewIntervalCalculator(64000, now)
+
+	_, err := NewDeparture(calc, "me@example.com").Leave("", now)
+	assert.ErrorIs(t, err, errNoSources)
+
+	departure := NewDeparture(calc, "me@example.com", 0x1)
+	_, err = departure.Leave(strings.Repeat("x", 256), now)
+	assert.ErrorIs(t, err, errReasonTooLong)
+	_, ok := departure.Poll(now)
+	assert.False(t, ok)
+
+	_, err = departure.Leave(strings.Repeat("x", 255), now)
+	assert.NoError(t, err)
+}
+
+func TestDepartureManySources(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	ssrcs := make([]uint32, 40)
+	for i := range ssrcs {
+		ssrcs[i] = uint32(i + 1) //nolint:gosec // G115
+	}
+	departure := NewDeparture(NewIntervalCalculator(64000, now), "me@example.com", ssrcs...)
+
+	_, err := departure.Leave("shutdown", now)
+	assert.NoError(t, err)
+	packet, ok := departure.Poll(now)
+	assert.True(t, ok)
+	assert.Len(t, packet, 4)
+	assert.Equal(t, &Goodbye{Sources: ssrcs[:31], Reason: "shutdown"}, packet[2])
+	assert.Equal(t, &Goodbye{Sources: ssrcs[31:], Reason: "shutdown"}, packet[3])
+
+	_, err = packet.Marshal()
+	assert.NoError(t, err)
+}
+
+func TestDepartureReconsideration(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	calc := NewIntervalCalculator(64000, now)
+	calc.Random = constantRandom(0.5)
+	calc.SetMembers(1000, 10, now)
+	departure := NewDeparture(calc, "me@example.com", 0x1)
+
+	sendAt, err := departure.Leave("", now)
+	assert.NoError(t, err)
+	assert.True(t, calc.Leaving())
+	assert.Equal(t, 1, calc.Members())
+
+	// As the only member having just joined, the BYE waits for half the
+	// minimum interval, compensated.
+	assert.Equal(t, now.Add(calc.Interval()), sendAt)
+	assert.Equal(t, rtcpMinTime/2, calc.DeterministicInterval())
+	assert.Equal(t, sendAt, departure.NextTransmission())
+	_, ok := departure.Poll(now)
+	assert.False(t, ok)
+
+	// Other packets do not change the group size while leaving.
+	calc.SetMembers(1000, 10, now)
+	departure.Receive([]Packet{&ReceiverReport{SSRC: 0xA}, NewCNAMESourceDescription(0xA, "a")}, 40)
+	assert.Equal(t, 1, calc.Members())
+
+	// Many members leaving at the same time push our BYE back.
+	for i := 0; i < 100; i++ {
+		ssrc := uint32(i + 0x100) //nolint:gosec // G115
+		departure.Receive([]Packet{&CompoundPacket{
+			&ReceiverReport{SSRC: ssrc},
+			NewCNAMESourceDescription(ssrc, "a"),
+			&Goodbye{Sources: []uint32{ssrc}},
+		}}, 40)
+	}
+	assert.Equal(t, 101, calc.Members())
+
+	_, ok = departure.Poll(sendAt)
+	assert.False(t, ok)
+	later := departure.NextTransmission()
+	assert.True(t, later.After(sendAt))
+
+	packet, ok := departure.Poll(later)
+	assert.True(t, ok)
+	assert.Equal(t, &Goodbye{Sources: []uint32{0x1}}, packet[2])
+}
@@ -38,4 +38,14 @@ var (
 	errAppDefinedInvalidLength  = errors.New("rtcp: application defined type invalid length")
 	errAppDefinedDataTooLarge   = errors.New("rtcp: application defined data is too large")
 	errAppDefinedInvalidName    = errors.New("rtcp: application defined name must be 4 ASCII chars")
+	errSSRCMismatch             = errors.New("rtcp: packet SSRC does not match")
+	errInvalidClockRate         = errors.New("rtcp: invalid clock rate")
+	errNoSenderReport           = errors.New("rtcp: no sender report received")
+	errCNAMEMismatch            = errors.New("rtcp: streams do not share a CNAME")
+	errNoSources                = errors.New("rtcp: no sources")
+	errPacketTooLarge           = errors.New("rtcp: packet does not fit in the MTU")
+	errPacketStatusCount        = errors.New("rtcp: packet chunks do not match the packet status count")
+	errRecvDeltaMismatch        = errors.New("rtcp: recv deltas do not match the packet status symbols")
+	errInvalidOverhead          = errors.New("rtcp: measured overhead does not fit in 9 bits")
+	errBitrateOverflow          = errors.New("rtcp: bitrate does not fit in 64 bits")
 )
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"slices"
+	"time"
+)
+
+// feedbackKey identifies the feedback of one sender about one media source.
+type feedbackKey struct {
+	sender uint32
+	media  uint32
+}
+
+type nackAggregate struct {
+	packet *TransportLayerNack
+	lost   []uint16
+}
+
+// FeedbackAggregator collects the feedback messages emitted over a window
+// and coalesces them into a minimal set of packets:
+//
+//   - TransportLayerNacks for the same media source are merged into one,
+//     with the lost packets deduplicated and the bitmaps recomputed.
+//   - Duplicate PictureLossIndications are collapsed into one.
+//   - FullIntraRequests of the same sender are combined into one carrying
+//     all FIREntries, keeping the latest entry per media source.
+//   - Only the latest ReceiverEstimatedMaximumBitrate of each sender is kept.
+//
+// Other packets are passed through. Packets are emitted in the order their
+// first occurrence was added, ready to be passed to Marshal.
+type FeedbackAggregator struct {
+	// Window is how long feedback is collected before Poll emits it.
+	Window time.Duration
+
+	start time.Time
+	out   []Packet
+	nacks map[feedbackKey]*nackAggregate
+	plis  map[feedbackKey]struct{}
+	firs  map[uint32]*FullIntraRequest
+	rembs map[uint32]int
+}
+
+// NewFeedbackAggregator creates a FeedbackAggregator collecting feedback
+// for the given window.
+func NewFeedbackAggregator(window time.Duration) *FeedbackAggregator {
+	a := &FeedbackAggregator{Window: window}
+	a.reset()
+
+	return a
+}
+
+// Add collects the feedback packets pkts, which may include
+// CompoundPackets, generated at time now.
+func (a *FeedbackAggregator) Add(pkts []Packet, now time.Time) {
+	if len(a.out) == 0 {
+		a.start = now
+	}
+
+	for _, pkt := range flattenPackets(pkts) {
+		switch p := pkt.(type) {
+		case *TransportLayerNack:
+			a.addNack(p)
+		case *PictureLossIndication:
+			key := feedbackKey{sender: p.SenderSSRC, media: p.MediaSSRC}
+			if _, ok := a.plis[key]; !ok {
+				a.plis[key] = struct{}{}
+				a.out = append(a.out, p)
+			}
+		case *FullIntraRequest:
+			a.addFullIntraRequest(p)
+		case *ReceiverEstimatedMaximumBitrate:
+			if i, ok := a.rembs[p.SenderSSRC]; ok {
+				a.out[i] = p
+			} else {
+				a.rembs[p.SenderSSRC] = len(a.out)
+				a.out = append(a.out, p)
+			}
+		default:
+			a.out = append(a.out, pkt)
+		}
+	}
+}
+
+// Pending reports whether feedback has been collected.
+func (a *FeedbackAggregator) Pending() bool {
+	return len(a.out) > 0
+}
+
+// Poll returns the coalesced feedback if the window has elapsed at time now
+// since the first packet was collected, and nil otherwise.
+func (a *FeedbackAggregator) Poll(now time.Time) []Packet {
+	if len(a.out) == 0 || now.Sub(a.start) < a.Window {
+		return nil
+	}
+
+	return a.Flush()
+}
+
+// Flush returns the coalesced feedback collected so far and starts a new
+// window.
+func (a *FeedbackAggregator) Flush() []Packet {
+	for _, nack := range a.nacks {
+		nack.packet.Nacks = NackPairsFromSequenceNumbers(nack.lost)
+	}
+
+	out := a.out
+	a.reset()
+
+	return out
+}
+
+func (a *FeedbackAggregator) reset() {
+	a.out = nil
+	a.nacks = map[feedbackKey]*nackAggregate{}
+	a.plis = map[feedbackKey]struct{}{}
+	a.firs = map[uint32]*FullIntraRequest{}
+	a.rembs = map[uint32]int{}
+}
+
+func (a *FeedbackAggregator) addNack(p *TransportLayerNack) {
+	lost := SequenceNumbersFromNacks(p)
+	if len(lost) == 0 {
+		return
+	}
+
+	key := feedbackKey{sender: p.SenderSSRC, media: p.MediaSSRC}
+	nack, ok := a.nacks[key]
+	if !ok {
+		nack = &nackAggregate{packet: &TransportLayerNack{SenderSSRC: p.SenderSSRC, MediaSSRC: p.MediaSSRC}}
+		a.nacks[key] = nack
+		a.out = append(a.out, nack.packet)
+	}
+	nack.lost = append(nack.lost, lost...)
+}
+
+func (a *FeedbackAggregator) addFullIntraRequest(p *FullIntraRequest) {
+	fir, ok := a.firs[p.SenderSSRC]
+	if !ok {
+		fir = &FullIntraRequest{SenderSSRC: p.SenderSSRC, MediaSSRC: p.MediaSSRC}
+		a.firs[p.SenderSSRC] = fir
+		a.out = append(a.out, fir)
+	}
+
+	for _, entry := range p.FIR {
+		i := slices.IndexFunc(fir.FIR, func(e FIREntry) bool { return e.SSRC == entry.SSRC })
+		if i < 0 {
+			fir.FIR = append(fir.FIR, entry)
+		} else {
+			fir.FIR[i] = entry
+		}
+	}
+}
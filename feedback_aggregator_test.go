@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedbackAggregatorCoalesce(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	aggregator := NewFeedbackAggregator(20 * time.Millisecond)
+
+	sli := &SliceLossIndication{SenderSSRC: 0x1, MediaSSRC: 0xA}
+	aggregator.Add([]Packet{
+		&TransportLayerNack{SenderSSRC: 0x1, MediaSSRC: 0xA, Nacks: NackPairsFromSequenceNumbers([]uint16{100, 102})},
+		&PictureLossIndication{SenderSSRC: 0x1, MediaSSRC: 0xA},
+		&ReceiverEstimatedMaximumBitrate{SenderSSRC: 0x1, Bitrate: 1000000, SSRCs: []uint32{0xA}},
+	}, now)
+	assert.True(t, aggregator.Pending())
+	assert.Nil(t, aggregator.Poll(now.Add(10*time.Millisecond)))
+
+	aggregator.Add([]Packet{
+		&CompoundPacket{
+			&ReceiverReport{SSRC: 0x1},
+			&TransportLayerNack{SenderSSRC: 0x1, MediaSSRC: 0xA, Nacks: NackPairsFromSequenceNumbers([]uint16{101, 102, 130})},
+		},
+		&TransportLayerNack{SenderSSRC: 0x1, MediaSSRC: 0xB, Nacks: NackPairsFromSequenceNumbers([]uint16{7})},
+		&PictureLossIndication{SenderSSRC: 0x1, MediaSSRC: 0xA},
+		&PictureLossIndication{SenderSSRC: 0x1, MediaSSRC: 0xB},
+		&FullIntraRequest{SenderSSRC: 0x1, FIR: []FIREntry{{SSRC: 0xA, SequenceNumber: 1}}},
+		&FullIntraRequest{SenderSSRC: 0x1, FIR: []FIREntry{{SSRC: 0xB, SequenceNumber: 4}, {SSRC: 0xA, SequenceNumber: 2}}},
+		&ReceiverEstimatedMaximumBitrate{SenderSSRC: 0x1, Bitrate: 500000, SSRCs: []uint32{0xA}},
+		sli,
+	}, now.Add(10*time.Millisecond))
+
+	out := aggregator.Poll(now.Add(20 * time.Millisecond))
+	assert.Equal(t, []Packet{
+		&TransportLayerNack{SenderSSRC: 0x1, MediaSSRC: 0xA, Nacks: []NackPair{
+			{PacketID: 100, LostPackets: 0b11},
+			{PacketID: 130},
+		}},
+		&PictureLossIndication{SenderSSRC: 0x1, MediaSSRC: 0xA},
+		&ReceiverEstimatedMaximumBitrate{SenderSSRC: 0x1, Bitrate: 500000, SSRCs: []uint32{0xA}},
+		&ReceiverReport{SSRC: 0x1},
+		&TransportLayerNack{SenderSSRC: 0x1, MediaSSRC: 0xB, Nacks: []NackPair{{PacketID: 7}}},
+		&PictureLossIndication{SenderSSRC: 0x1, MediaSSRC: 0xB},
+		&FullIntraRequest{SenderSSRC: 0x1, FIR: []FIREntry{{SSRC: 0xA, SequenceNumber: 2}, {SSRC: 0xB, SequenceNumber: 4}}},
+		sli,
+	}, out)
+
+	_, err := Marshal(out)
+	assert.NoError(t, err)
+
+	assert.False(t, aggregator.Pending())
+	assert.Nil(t, aggregator.Poll(now.Add(time.Second)))
+}
+
+func TestFeedbackAggregatorNackWraparound(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	aggregator := NewFeedbackAggregator(0)
+
+	aggregator.Add([]Packet{
+		&TransportLayerNack{SenderSSRC: 0x1, MediaSSRC: 0xA, Nacks: NackPairsFromSequenceNumbers([]uint16{65534, 1})},
+		&TransportLayerNack{SenderSSRC: 0x1, MediaSSRC: 0xA, Nacks: NackPairsFromSequenceNumbers([]uint16{65535, 0})},
+		// Another sender's NACK is kept apart.
+		&TransportLayerNack{SenderSSRC: 0x2, MediaSSRC: 0xA, Nacks: NackPairsFromSequenceNumbers([]uint16{0})},
+	}, now)
+
+	assert.Equal(t, []Packet{
+		&TransportLayerNack{SenderSSRC: 0x1, MediaSSRC: 0xA, Nacks: []NackPair{{PacketID: 65534, LostPackets: 0b111}}},
+		&TransportLayerNack{SenderSSRC: 0x2, MediaSSRC: 0xA, Nacks: []NackPair{{PacketID: 0}}},
+	}, aggregator.Poll(now))
+}
@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// RTPPacketID identifies an RTP packet by its stream and sequence number.
+type RTPPacketID struct {
+	SSRC           uint32
+	SequenceNumber uint16
+}
+
+// TransportSequenceMap maps the transport-wide sequence numbers of the RTP
+// packets sent to the stream and sequence number of each packet, for
+// translating between TransportLayerCC and CCFeedbackReport feedback. It
+// holds at most one entry per transport-wide sequence number and per
+// packet, so entries are replaced as sequence numbers wrap.
+type TransportSequenceMap struct {
+	packets   map[uint16]RTPPacketID
+	transport map[RTPPacketID]uint16
+}
+
+// NewTransportSequenceMap creates an empty TransportSequenceMap.
+func NewTransportSequenceMap() *TransportSequenceMap {
+	return &TransportSequenceMap{
+		packets:   map[uint16]RTPPacketID{},
+		transport: map[RTPPacketID]uint16{},
+	}
+}
+
+// Add records that the packet with sequence number seq of the stream ssrc
+// was sent with transport-wide sequence number transportSeq.
+func (m *TransportSequenceMap) Add(transportSeq uint16, ssrc uint32, seq uint16) {
+	m.Remove(transportSeq)
+	id := RTPPacketID{SSRC: ssrc, SequenceNumber: seq}
+	if previous, ok := m.transport[id]; ok {
+		delete(m.packets, previous)
+	}
+	m.packets[transportSeq] = id
+	m.transport[id] = transportSeq
+}
+
+// Remove drops the entry of transportSeq.
+func (m *TransportSequenceMap) Remove(transportSeq uint16) {
+	if id, ok := m.packets[transportSeq]; ok {
+		delete(m.transport, id)
+		delete(m.packets, transportSeq)
+	}
+}
+
+// Packet returns the packet sent with transportSeq.
+func (m *TransportSequenceMap) Packet(transportSeq uint16) (RTPPacketID, bool) {
+	id, ok := m.packets[transportSeq]
+
+	return id, ok
+}
+
+// TransportSequenceNumber returns the transport-wide sequence number the
+// packet id was sent with.
+func (m *TransportSequenceMap) TransportSequenceNumber(id RTPPacketID) (uint16, bool) {
+	seq, ok := m.transport[id]
+
+	return seq, ok
+}
+
+// TWCCToCCFB translates the TransportLayerCC tcc into a CCFeedbackReport,
+// using mapping to find the packet of each transport-wide sequence number.
+// It also returns the transport-wide sequence numbers that are not in
+// mapping. The report is nil if no packet could be mapped.
+//
+// A report block is emitted for each run of consecutive sequence numbers of
+// a stream, so that the packets not covered by tcc are not reported as
+// lost. The Report Timestamp is the latest arrival time on the clock of the
+// receiver, or the reference time of tcc if no packet was received, and
+// arrival times are rounded to 1/1024 seconds. ECN is not
+// reported by TransportLayerCC and is set to ECNNonECT.
+func TWCCToCCFB(tcc *TransportLayerCC, mapping *TransportSequenceMap) (*CCFeedbackReport, []uint16, error) {
+	results, err := tcc.PacketResults()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The reference time stands for the arrival times if no packet was
+	// received.
+	latest := time.UnixMicro(int64(tcc.ReferenceTime) * tccBaseTimeTick)
+	received := false
+	for _, result := range results {
+		if result.Received && (!received || result.Arrival.After(latest)) {
+			latest = result.Arrival
+			received = true
+		}
+	}
+	report := &CCFeedbackReport{
+		SenderSSRC:      tcc.SenderSSRC,
+		ReportTimestamp: toCompactNTP(toNTPTime(latest)),
+	}
+
+	var unmapped []uint16
+	streams := map[uint32]map[uint16]CCFeedbackMetricBlock{}
+	for _, result := range results {
+		id, ok := mapping.Packet(result.SequenceNumber)
+		if !ok {
+			unmapped = append(unmapped, result.SequenceNumber)
+
+			continue
+		}
+
+		metric := CCFeedbackMetricBlock{Received: result.Received}
+		if result.Received {
+			metric.ArrivalTimeOffset = ccfbArrivalTimeUnavailable
+			if !result.Arrival.IsZero() {
+				metric.ArrivalTimeOffset = arrivalTimeOffset(report.ReportTimestamp, result.Arrival)
+			}
+		}
+
+		if streams[id.SSRC] == nil {
+			streams[id.SSRC] = map[uint16]CCFeedbackMetricBlock{}
+		}
+		if previous, ok := streams[id.SSRC][id.SequenceNumber]; !ok || !previous.Received {
+			streams[id.SSRC][id.SequenceNumber] = metric
+		}
+	}
+
+	ssrcs := make([]uint32, 0, len(streams))
+	for ssrc := range streams {
+		ssrcs = append(ssrcs, ssrc)
+	}
+	slices.Sort(ssrcs)
+
+	for _, ssrc := range ssrcs {
+		metrics := streams[ssrc]
+		for _, run := range sequenceNumberRuns(metrics) {
+			block := CCFeedbackReportBlock{MediaSSRC: ssrc, BeginSequence: run[0]}
+			for _, seq := range run {
+				block.MetricBlocks = append(block.MetricBlocks, metrics[seq])
+			}
+			report.ReportBlocks = append(report.ReportBlocks, block)
+		}
+	}
+	if len(report.ReportBlocks) == 0 {
+		return nil, unmapped, nil
+	}
+
+	return report, unmapped, nil
+}
+
+type tccTranslation struct {
+	received bool
+	arrival  time.Time
+}
+
+// CCFBToTWCC translates the CCFeedbackReport report, received at time now,
+// into TransportLayerCC packets numbered from *fbPktCount, using mapping to
+// find the transport-wide sequence number of each packet. *fbPktCount is
+// advanced past the packets returned, ready for the next report. It also returns
+// the packets that are not in mapping, and those reported as received
+// without an arrival time, which TransportLayerCC cannot represent.
+//
+// A TransportLayerCC is emitted for each run of consecutive transport-wide
+// sequence numbers, so that the packets not covered by report are not
+// reported as lost, and is split as TWCCRecorder does. As in the packets
+// built by TWCCRecorder, the lost packets following the last received one
+// of a run are not reported. The media SSRC is that of the first report
+// block, and arrival times are rounded to 250µs. Arrival times are resolved
+// to the wall clock as by a CCFeedbackTimeline, so that they do not jump
+// when the compact NTP time wraps within the report.
+func CCFBToTWCC(
+	report *CCFeedbackReport,
+	mapping *TransportSequenceMap,
+	fbPktCount *uint8,
+	now time.Time,
+) ([]*TransportLayerCC, []RTPPacketID) {
+	var unmapped []RTPPacketID
+	packets := map[uint16]tccTranslation{}
+	for ssrc, results := range report.PacketResults(now) {
+		for _, result := range results {
+			id := RTPPacketID{SSRC: ssrc, SequenceNumber: uint16(result.SequenceNumber)} //nolint:gosec // G115
+			transportSeq, ok := mapping.TransportSequenceNumber(id)
+			if !ok || (result.Received && result.Arrival.IsZero()) {
+				unmapped = append(unmapped, id)
+
+				continue
+			}
+			if previous, ok := packets[transportSeq]; !ok || !previous.received {
+				packets[transportSeq] = tccTranslation{received: result.Received, arrival: result.Arrival}
+			}
+		}
+	}
+	slices.SortFunc(unmapped, func(a, b RTPPacketID) int {
+		if a.SSRC != b.SSRC {
+			return cmp.Compare(a.SSRC, b.SSRC)
+		}
+
+		return cmp.Compare(a.SequenceNumber, b.SequenceNumber)
+	})
+
+	var mediaSSRC uint32
+	if len(report.ReportBlocks) > 0 {
+		mediaSSRC = report.ReportBlocks[0].MediaSSRC
+	}
+
+	var out []*TransportLayerCC
+	for _, run := range sequenceNumberRuns(packets) {
+		start := int64(run[0])
+		out = append(out, buildTransportLayerCCs(start, start+int64(len(run))-1, func(seq int64) (time.Time, bool) {
+			packet := packets[uint16(seq)] //nolint:gosec // G115
+
+			return packet.arrival, packet.received
+		}, defaultTWCCMTU, report.SenderSSRC, mediaSSRC, fbPktCount)...)
+	}
+
+	return out, unmapped
+}
+
+// sequenceNumberRuns returns the sequence numbers of seqs in serial number
+// order, split into runs of consecutive numbers.
+func sequenceNumberRuns[T any](seqs map[uint16]T) [][]uint16 {
+	keys := make([]uint16, 0, len(seqs))
+	for seq := range seqs {
+		keys = append(keys, seq)
+	}
+
+	var runs [][]uint16
+	for i, seq := range serialSortSequenceNumbers(keys) {
+		if i == 0 || seq != runs[len(runs)-1][len(runs[len(runs)-1])-1]+1 {
+			runs = append(runs, nil)
+		}
+		runs[len(runs)-1] = append(runs[len(runs)-1], seq)
+	}
+
+	return runs
+}
@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransportSequenceMap(t *testing.T) {
+	mapping := NewTransportSequenceMap()
+	mapping.Add(1, 0xA, 10)
+	mapping.Add(2, 0xA, 11)
+
+	id, ok := mapping.Packet(1)
+	assert.True(t, ok)
+	assert.Equal(t, RTPPacketID{SSRC: 0xA, SequenceNumber: 10}, id)
+	seq, ok := mapping.TransportSequenceNumber(RTPPacketID{SSRC: 0xA, SequenceNumber: 11})
+	assert.True(t, ok)
+	assert.Equal(t, uint16(2), seq)
+
+	// Entries are replaced in both directions.
+	mapping.Add(1, 0xA, 12)
+	_, ok = mapping.TransportSequenceNumber(RTPPacketID{SSRC: 0xA, SequenceNumber: 10})
+	assert.False(t, ok)
+	mapping.Add(3, 0xA, 11)
+	_, ok = mapping.Packet(2)
+	assert.False(t, ok)
+
+	mapping.Remove(3)
+	_, ok = mapping.TransportSequenceNumber(RTPPacketID{SSRC: 0xA, SequenceNumber: 11})
+	assert.False(t, ok)
+}
+
+// arrivalOffsets returns arrivals relative to the first one.
+func arrivalOffsets(arrivals []time.Time) []time.Duration {
+	offsets := make([]time.Duration, 0, len(arrivals))
+	for _, arrival := range arrivals {
+		offsets = append(offsets, arrival.Sub(arrivals[0]))
+	}
+
+	return offsets
+}
+
+func TestTWCCToCCFB(t *testing.T) {
+	mapping := NewTransportSequenceMap()
+	mapping.Add(65534, 0xA, 10)
+	mapping.Add(65535, 0xB, 65535)
+	mapping.Add(0, 0xA, 11)
+	mapping.Add(1, 0xB, 0)
+	mapping.Add(2, 0xA, 12)
+	mapping.Add(4, 0xA, 14)
+
+	start := time.Unix(1700000000, 0)
+	recorder := NewTWCCRecorder(0x1, 0xA)
+	recorder.Record(65534, start)
+	recorder.Record(65535, start.Add(10*time.Millisecond))
+	recorder.Record(1, start.Add(20*time.Millisecond))
+	recorder.Record(2, start.Add(25*time.Millisecond))
+	recorder.Record(4, start.Add(30*time.Millisecond))
+	recorder.Record(5, start.Add(40*time.Millisecond))
+	pkts := recorder.BuildFeedbackPackets()
+	assert.Len(t, pkts, 1)
+	tcc, ok := pkts[0].(*TransportLayerCC)
+	assert.True(t, ok)
+
+	report, unmapped, err := TWCCToCCFB(tcc, mapping)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint16{3, 5}, unmapped)
+	assert.Equal(t, uint32(0x1), report.SenderSSRC)
+
+	// Lost packets are reported, packets not covered by the feedback are not.
+	assert.Len(t, report.ReportBlocks, 3)
+	assert.Equal(t, uint32(0xA), report.ReportBlocks[0].MediaSSRC)
+	assert.Equal(t, uint16(10), report.ReportBlocks[0].BeginSequence)
+	assert.Equal(t, uint32(0xA), report.ReportBlocks[1].MediaSSRC)
+	assert.Equal(t, uint16(14), report.ReportBlocks[1].BeginSequence)
+	assert.Equal(t, uint32(0xB), report.ReportBlocks[2].MediaSSRC)
+	assert.Equal(t, uint16(65535), report.ReportBlocks[2].BeginSequence)
+
+	results := report.PacketResults(start.Add(time.Second))
+	var received []bool
+	var arrivals []time.Time
+	for _, result := range append(results[0xA], results[0xB]...) {
+		received = append(received, result.Received)
+		if result.Received {
+			arrivals = append(arrivals, result.Arrival)
+		}
+	}
+	assert.Equal(t, []bool{true, false, true, true, true, true}, received)
+	// Packets 10, 12, 14, 65535 and 0, received 0, 25, 30, 10 and 20ms
+	// after the first, within the precision of 1/1024 seconds.
+	for i, want := range []time.Duration{0, 25, 30, 10, 20} {
+		assert.InDelta(t, want*time.Millisecond, arrivalOffsets(arrivals)[i], float64(time.Second/1024))
+	}
+
+	report, unmapped, err = TWCCToCCFB(tcc, NewTransportSequenceMap())
+	assert.NoError(t, err)
+	assert.Nil(t, report)
+	assert.Len(t, unmapped, 8)
+
+	_, _, err = TWCCToCCFB(&TransportLayerCC{PacketStatusCount: 1}, mapping)
+	assert.ErrorIs(t, err, errPacketStatusCount)
+}
+
+func TestTWCCToCCFBNoneReceived(t *testing.T) {
+	mapping := NewTransportSequenceMap()
+	mapping.Add(10, 0xA, 100)
+	mapping.Add(11, 0xA, 101)
+	tcc := &TransportLayerCC{
+		SenderSSRC:         0x1,
+		MediaSSRC:          0xA,
+		BaseSequenceNumber: 10,
+		PacketStatusCount:  2,
+		ReferenceTime:      1000,
+		PacketChunks: []PacketStatusChunk{
+			&RunLengthChunk{Type: TypeTCCRunLengthChunk, PacketStatusSymbol: TypeTCCPacketNotReceived, RunLength: 2},
+		},
+	}
+
+	// The Report Timestamp is the reference time.
+	report, unmapped, err := TWCCToCCFB(tcc, mapping)
+	assert.NoError(t, err)
+	assert.Empty(t, unmapped)
+	assert.Equal(t, toCompactNTP(toNTPTime(time.UnixMicro(1000*tccBaseTimeTick))), report.ReportTimestamp)
+	assert.Equal(t, []CCFeedbackReportBlock{
+		{MediaSSRC: 0xA, BeginSequence: 100, MetricBlocks: []CCFeedbackMetricBlock{{}, {}}},
+	}, report.ReportBlocks)
+}
+
+func TestCCFBToTWCC(t *testing.T) {
+	mapping := NewTransportSequenceMap()
+	mapping.Add(100, 0xA, 10)
+	mapping.Add(101, 0xB, 20)
+	mapping.Add(102, 0xA, 11)
+	mapping.Add(103, 0xA, 12)
+	mapping.Add(200, 0xB, 21)
+	mapping.Add(201, 0xB, 22)
+
+	now := time.Unix(1700000000, 0)
+	recorder := NewCCFBRecorder(0x1)
+	recorder.Record(0xA, 10, now.Add(-40*time.Millisecond), ECNECT0)
+	recorder.Record(0xB, 20, now.Add(-30*time.Millisecond), ECNECT0)
+	recorder.Record(0xA, 12, now.Add(-20*time.Millisecond), ECNECT0)
+	recorder.Record(0xB, 22, now.Add(-10*time.Millisecond), ECNECT0)
+	recorder.Record(0xB, 23, now, ECNECT0)
+	report := recorder.BuildReport(now)
+	// A packet received without an arrival time.
+	report.ReportBlocks[1].MetricBlocks[1] = CCFeedbackMetricBlock{Received: true, ArrivalTimeOffset: 0x1FFF}
+
+	fbPktCount := uint8(7)
+	tccs, unmapped := CCFBToTWCC(report, mapping, &fbPktCount, now.Add(20*time.Millisecond))
+	assert.Equal(t, []RTPPacketID{{SSRC: 0xB, SequenceNumber: 21}, {SSRC: 0xB, SequenceNumber: 23}}, unmapped)
+
+	// One packet per run of transport-wide sequence numbers.
+	assert.Len(t, tccs, 2)
+	var arrivals []time.Time
+	for i, tcc := range tccs {
+		assert.Equal(t, uint32(0x1), tcc.SenderSSRC)
+		assert.Equal(t, uint32(0xA), tcc.MediaSSRC)
+		assert.Equal(t, uint8(7+i), tcc.FbPktCount) //nolint:gosec // G115
+
+		raw, err := tcc.Marshal()
+		assert.NoError(t, err)
+		decoded := &TransportLayerCC{}
+		assert.NoError(t, decoded.Unmarshal(raw))
+
+		results, err := decoded.PacketResults()
+		assert.NoError(t, err)
+		for _, result := range results {
+			if result.Received {
+				arrivals = append(arrivals, result.Arrival)
+			}
+		}
+	}
+	assert.Equal(t, uint16(100), tccs[0].BaseSequenceNumber)
+	assert.Equal(t, uint16(4), tccs[0].PacketStatusCount)
+	assert.Equal(t, uint16(201), tccs[1].BaseSequenceNumber)
+	assert.Equal(t, uint16(1), tccs[1].PacketStatusCount)
+
+	// Packets 100, 101, 103 and 201, within the precision of 1/1024 seconds.
+	for i, want := range []time.Duration{0, 10, 20, 30} {
+		assert.InDelta(t, want*time.Millisecond, arrivalOffsets(arrivals)[i], float64(time.Second/1024))
+	}
+
+	// The next report continues the numbering.
+	assert.Equal(t, uint8(9), fbPktCount)
+	mapping.Add(202, 0xB, 24)
+	recorder.Record(0xB, 24, now.Add(10*time.Millisecond), ECNECT0)
+	tccs, _ = CCFBToTWCC(recorder.BuildReport(now.Add(20*time.Millisecond)), mapping, &fbPktCount, now.Add(40*time.Millisecond))
+	assert.Len(t, tccs, 1)
+	assert.Equal(t, uint8(9), tccs[0].FbPktCount)
+	assert.Equal(t, uint8(10), fbPktCount)
+}
+
+func TestCCFBToTWCCCompactNTPWrap(t *testing.T) {
+	mapping := NewTransportSequenceMap()
+	for seq := uint16(0); seq < 5; seq++ {
+		mapping.Add(seq, 0xA, seq)
+	}
+
+	// The compact NTP time wraps at the Unix time 1700036992, between the
+	// arrivals of the packets.
+	wrap := time.Unix(1700036992, 0)
+	recorder := NewCCFBRecorder(0x1)
+	for seq := uint16(0); seq < 5; seq++ {
+		recorder.Record(0xA, seq, wrap.Add(time.Duration(seq)*10*time.Millisecond-20*time.Millisecond), ECNECT0)
+	}
+	report := recorder.BuildReport(wrap.Add(30 * time.Millisecond))
+
+	var fbPktCount uint8
+	tccs, unmapped := CCFBToTWCC(report, mapping, &fbPktCount, wrap.Add(50*time.Millisecond))
+	assert.Empty(t, unmapped)
+	if !assert.Len(t, tccs, 1) {
+		return
+	}
+	assert.Equal(t, uint16(5), tccs[0].PacketStatusCount)
+	results, err := tccs[0].PacketResults()
+	assert.NoError(t, err)
+	var arrivals []time.Time
+	for _, result := range results {
+		arrivals = append(arrivals, result.Arrival)
+	}
+	for i, want := range []time.Duration{0, 10, 20, 30, 40} {
+		assert.InDelta(t, want*time.Millisecond, arrivalOffsets(arrivals)[i], float64(time.Second/1024))
+	}
+}
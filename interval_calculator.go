@@ -0,0 +1,317 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Constants of the RTCP transmission interval algorithm, RFC 3550 Appendix A.7.
+const (
+	// Minimum average time between RTCP packets from this site.
+	rtcpMinTime = 5 * time.Second
+	// Fraction of the RTCP bandwidth to be shared among active senders.
+	rtcpSenderBandwidthFraction = 0.25
+	// Fraction of the session bandwidth used for RTCP.
+	rtcpBandwidthFraction = 0.05
+	// To compensate for "timer reconsideration" converging to a value
+	// below the intended average.
+	rtcpCompensation = math.E - 1.5
+
+	// Default size of lower-layer headers (IPv4 + UDP) per RTCP packet.
+	defaultRTCPOverhead = 28
+	// Default estimate of the size of the first compound packet, an empty
+	// ReceiverReport plus a SourceDescription with a short CNAME.
+	defaultInitialRTCPSize = 64
+	// Group size from which BYE reconsideration is used, RFC 3550
+	// Section 6.3.7.
+	byeReconsiderationMembers = 50
+)
+
+// IntervalCalculator implements the computation of the RTCP transmission
+// interval described in RFC 3550 Section 6.3 and Appendix A.7, including the
+// randomization and compensation of the interval, timer reconsideration,
+// reverse reconsideration when members leave and the reduced minimum
+// interval. The RTCP bandwidth is derived from the session bandwidth, or
+// from the RS and RR bandwidth modifiers of RFC 3556 when they are set.
+//
+// The calculator does not run any timers. Time is passed in by the caller,
+// and the random source can be replaced, so it is fully deterministic.
+type IntervalCalculator struct {
+	// Session bandwidth in bits per second.
+	SessionBandwidth float64
+	// RTCP bandwidth in bits per second allocated to active senders and to
+	// other participants, as signaled with the RFC 3556 "b=RS" and "b=RR"
+	// modifiers. They are used instead of the 5% of the session bandwidth
+	// when HasBandwidthModifiers is set.
+	SenderBandwidth       float64
+	ReceiverBandwidth     float64
+	HasBandwidthModifiers bool
+	// Use the reduced minimum interval of 360 / session bandwidth in
+	// kilobits per second instead of 5 seconds, see RFC 3550 Section 6.2.
+	ReducedMinimum bool
+	// Size of the lower-layer headers added to each RTCP packet, in octets.
+	Overhead int
+	// Random returns a pseudo-random number in [0.0, 1.0). It defaults to
+	// math/rand.Float64 and can be replaced for deterministic intervals.
+	Random func() float64
+
+	members     int
+	pmembers    int
+	senders     int
+	weSent      bool
+	initial     bool
+	leaving     bool
+	avgRTCPSize float64
+	tp          time.Time
+	tn          time.Time
+}
+
+// NewIntervalCalculator creates an IntervalCalculator for a session with the
+// given bandwidth in bits per second which is joined at time now. The first
+// compound packet is scheduled one initial interval later.
+func NewIntervalCalculator(sessionBandwidth float64, now time.Time) *IntervalCalculator {
+	return &IntervalCalculator{
+		SessionBandwidth: sessionBandwidth,
+		Overhead:         defaultRTCPOverhead,
+		Random:           rand.Float64, //nolint:gosec // G404, timing jitter does not need a secure source
+		members:          1,
+		pmembers:         1,
+		initial:          true,
+		avgRTCPSize:      defaultInitialRTCPSize + defaultRTCPOverhead,
+		tp:               now,
+	}
+}
+
+// SetMembers updates the number of session members and active senders,
+// including ourselves. When the number of members decreases, the next
+// transmission time is brought forward as required by the reverse
+// reconsideration algorithm of RFC 3550 Section 6.3.4.
+func (c *IntervalCalculator) SetMembers(members, senders int, now time.Time) {
+	if c.leaving {
+		return
+	}
+
+	c.members = max(members, 1)
+	c.senders = min(max(senders, 0), c.members)
+
+	if c.members >= c.pmembers {
+		c.pmembers = c.members
+
+		return
+	}
+
+	c.schedule()
+	ratio := float64(c.members) / float64(c.pmembers)
+	c.tn = now.Add(time.Duration(ratio * float64(c.tn.Sub(now))))
+	c.tp = now.Add(-time.Duration(ratio * float64(now.Sub(c.tp))))
+	c.pmembers = c.members
+}
+
+// Members returns the number of session members.
+func (c *IntervalCalculator) Members() int {
+	return c.members
+}
+
+// Senders returns the number of active senders.
+func (c *IntervalCalculator) Senders() int {
+	return c.senders
+}
+
+// SetWeSent records whether we have sent RTP data since the second-to-last
+// RTCP report, which decides the share of the RTCP bandwidth we use.
+func (c *IntervalCalculator) SetWeSent(weSent bool) {
+	c.weSent = weSent
+}
+
+// AverageRTCPSize returns the average compound RTCP packet size in octets,
+// including lower-layer headers.
+func (c *IntervalCalculator) AverageRTCPSize() float64 {
+	return c.avgRTCPSize
+}
+
+// PacketReceived updates the average compound RTCP packet size with a
+// compound packet of size octets received from another participant. It has
+// no effect once Leave has been called.
+func (c *IntervalCalculator) PacketReceived(size int) {
+	if !c.leaving {
+		c.updateAverage(size)
+	}
+}
+
+// Leave starts leaving the session at time now with a compound BYE packet of
+// byeSize octets, following RFC 3550 Section 6.3.7. It returns true if the
+// BYE may be sent right away, which is the case for groups of less than 50
+// members. Otherwise the BYE is subject to reconsideration as if we had just
+// joined a session of which we are the only member: the group size only
+// grows with the BYE packets received, see GoodbyeReceived, and Reconsider
+// tells when the BYE is to be sent.
+func (c *IntervalCalculator) Leave(byeSize int, now time.Time) bool {
+	if c.members < byeReconsiderationMembers {
+		return true
+	}
+
+	c.leaving = true
+	c.members = 1
+	c.pmembers = 1
+	c.senders = 0
+	c.weSent = false
+	c.initial = true
+	c.avgRTCPSize = float64(byeSize + c.Overhead)
+	c.tp = now
+	c.tn = now.Add(c.Interval())
+
+	return false
+}
+
+// Leaving reports whether BYE reconsideration is in progress.
+func (c *IntervalCalculator) Leaving() bool {
+	return c.leaving
+}
+
+// GoodbyeReceived counts a compound packet of size octets received while
+// leaving the session, which carried the given number of Goodbye packets.
+// Each Goodbye counts as one member, whether or not its sources were
+// members of the session. It has no effect unless Leave has been called.
+func (c *IntervalCalculator) GoodbyeReceived(size, goodbyes int) {
+	if !c.leaving || goodbyes == 0 {
+		return
+	}
+
+	c.members += goodbyes
+	c.pmembers = c.members
+	c.updateAverage(size)
+}
+
+// PacketSent updates the average compound RTCP packet size with a compound
+// packet of size octets that was sent at time now, and schedules the next
+// transmission.
+func (c *IntervalCalculator) PacketSent(size int, now time.Time) {
+	c.updateAverage(size)
+	c.initial = false
+	c.tp = now
+	c.tn = now.Add(c.Interval())
+}
+
+// Reconsider implements timer reconsideration for the transmission timer
+// expiring at time now. It returns true if a compound packet should be sent
+// now, after which PacketSent must be called. Otherwise the transmission has
+// been rescheduled to NextTransmission.
+func (c *IntervalCalculator) Reconsider(now time.Time) bool {
+	c.schedule()
+	if !c.Enabled() || now.Before(c.tn) {
+		return false
+	}
+
+	tn := c.tp.Add(c.Interval())
+	if tn.After(now) {
+		c.tn = tn
+
+		return false
+	}
+
+	return true
+}
+
+// NextTransmission returns the time at which the next compound packet is scheduled.
+func (c *IntervalCalculator) NextTransmission() time.Time {
+	c.schedule()
+
+	return c.tn
+}
+
+// LastTransmission returns the time at which the last compound packet was
+// sent, or the time the session was joined.
+func (c *IntervalCalculator) LastTransmission() time.Time {
+	return c.tp
+}
+
+// Enabled reports whether we are allowed to send RTCP at all. Setting both
+// bandwidth modifiers to zero disables RTCP, and setting only the receiver
+// bandwidth to zero disables it for participants that are not senders.
+func (c *IntervalCalculator) Enabled() bool {
+	bw, _ := c.bandwidth()
+
+	return bw > 0
+}
+
+// DeterministicInterval returns the calculated interval Td between compound
+// packets, before randomization and compensation. It returns zero if RTCP
+// is not enabled.
+func (c *IntervalCalculator) DeterministicInterval() time.Duration {
+	rtcpBandwidth, n := c.bandwidth()
+	if rtcpBandwidth <= 0 {
+		return 0
+	}
+
+	minTime := rtcpMinTime
+	if c.ReducedMinimum && c.SessionBandwidth > 0 {
+		minTime = time.Duration(360 / (c.SessionBandwidth / 1000) * float64(time.Second))
+	}
+	if c.initial {
+		minTime /= 2
+	}
+
+	t := time.Duration(c.avgRTCPSize * float64(n) / rtcpBandwidth * float64(time.Second))
+
+	return max(t, minTime)
+}
+
+// Interval returns the randomized transmission interval T, uniformly
+// distributed between 0.5 and 1.5 times the deterministic interval and
+// divided by e-3/2 to compensate for timer reconsideration.
+func (c *IntervalCalculator) Interval() time.Duration {
+	random := rand.Float64 //nolint:gosec // G404
+	if c.Random != nil {
+		random = c.Random
+	}
+
+	t := float64(c.DeterministicInterval()) * (random() + 0.5)
+
+	return time.Duration(t / rtcpCompensation)
+}
+
+// bandwidth returns the RTCP bandwidth in octets per second available to us
+// and the number of participants it is shared with.
+func (c *IntervalCalculator) bandwidth() (float64, int) {
+	rtcpBandwidth := c.SessionBandwidth * rtcpBandwidthFraction
+	senderFraction := rtcpSenderBandwidthFraction
+	if c.HasBandwidthModifiers {
+		rtcpBandwidth = c.SenderBandwidth + c.ReceiverBandwidth
+		if rtcpBandwidth <= 0 {
+			return 0, 0
+		}
+		senderFraction = c.SenderBandwidth / rtcpBandwidth
+	}
+	rtcpBandwidth /= 8
+
+	// Once the number of senders exceeds their share of the bandwidth,
+	// everybody shares the RTCP bandwidth equally.
+	n := c.members
+	if float64(c.senders) <= float64(c.members)*senderFraction {
+		if c.weSent {
+			rtcpBandwidth *= senderFraction
+			n = c.senders
+		} else {
+			rtcpBandwidth *= 1 - senderFraction
+			n -= c.senders
+		}
+	}
+
+	return rtcpBandwidth, max(n, 1)
+}
+
+// schedule computes the first transmission time, which is deferred until it
+// is needed so that Random can be replaced after construction.
+func (c *IntervalCalculator) schedule() {
+	if c.tn.IsZero() {
+		c.tn = c.tp.Add(c.Interval())
+	}
+}
+
+func (c *IntervalCalculator) updateAverage(size int) {
+	c.avgRTCPSize = float64(size+c.Overhead)/16 + c.avgRTCPSize*15/16
+}
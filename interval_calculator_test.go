@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func constantRandom(v float64) func() float64 {
+	return func() float64 { return v }
+}
+
+func secondsDuration(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+func TestIntervalCalculatorDeterministicInterval(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	for _, test := range []struct {
+		Name      string
+		Calc      IntervalCalculator
+		Members   int
+		Senders   int
+		WeSent    bool
+		AvgSize   float64
+		Initial   bool
+		WantTd    time.Duration
+		WantDelta time.Duration
+	}{
+		{
+			// 5% of 64 kb/s is 400 octets/s, of which receivers get 75%.
+			Name:    "large audio conference, receiver",
+			Calc:    IntervalCalculator{SessionBandwidth: 64000},
+			Members: 1000, Senders: 10, AvgSize: 128,
+			WantTd: secondsDuration(128.0 * 990 / 300),
+		},
+		{
+			// Senders share 25% of the RTCP bandwidth among themselves.
+			Name:    "large audio conference, sender",
+			Calc:    IntervalCalculator{SessionBandwidth: 64000},
+			Members: 1000, Senders: 10, WeSent: true, AvgSize: 128,
+			WantTd: secondsDuration(128.0 * 10 / 100),
+		},
+		{
+			// With more than 25% senders, the bandwidth is shared equally.
+			Name:    "many senders",
+			Calc:    IntervalCalculator{SessionBandwidth: 64000},
+			Members: 1000, Senders: 500, WeSent: true, AvgSize: 128,
+			WantTd: secondsDuration(128.0 * 1000 / 400),
+		},
+		{
+			Name:    "two party call is clamped to the minimum",
+			Calc:    IntervalCalculator{SessionBandwidth: 64000},
+			Members: 2, Senders: 2, WeSent: true, AvgSize: 128,
+			WantTd: 5 * time.Second,
+		},
+		{
+			Name:    "initial minimum is halved",
+			Calc:    IntervalCalculator{SessionBandwidth: 64000},
+			Members: 2, Senders: 2, WeSent: true, AvgSize: 128, Initial: true,
+			WantTd: 2500 * time.Millisecond,
+		},
+		{
+			// RFC 3550 Section 6.2: 360 divided by the session bandwidth in
+			// kilobits/second, 0.36 seconds for 1 Mb/s.
+			Name:    "reduced minimum",
+			Calc:    IntervalCalculator{SessionBandwidth: 1000000, ReducedMinimum: true},
+			Members: 2, Senders: 2, WeSent: true, AvgSize: 128,
+			WantTd: 360 * time.Millisecond,
+		},
+		{
+			// RFC 3556: RS=2000 and RR=6000 bits/s reproduce the defaults of a
+			// 160 kb/s session.
+			Name: "bandwidth modifiers",
+			Calc: IntervalCalculator{
+				SessionBandwidth:      64000,
+				SenderBandwidth:       2000,
+				ReceiverBandwidth:     6000,
+				HasBandwidthModifiers: true,
+			},
+			Members: 1000, Senders: 10, AvgSize: 128,
+			WantTd: secondsDuration(128.0 * 990 / 750),
+		},
+		{
+			Name: "receivers disabled by RR=0",
+			Calc: IntervalCalculator{
+				SenderBandwidth:       2000,
+				HasBandwidthModifiers: true,
+			},
+			Members: 10, Senders: 1, AvgSize: 128,
+			WantTd: 0,
+		},
+		{
+			Name: "senders still report with RR=0",
+			Calc: IntervalCalculator{
+				SenderBandwidth:       2000,
+				HasBandwidthModifiers: true,
+			},
+			Members: 10, Senders: 1, WeSent: true, AvgSize: 128,
+			WantTd: 5 * time.Second,
+		},
+		{
+			Name: "disabled by RS=0 and RR=0",
+			Calc: IntervalCalculator{
+				SessionBandwidth:      64000,
+				HasBandwidthModifiers: true,
+			},
+			Members: 10, Senders: 1, WeSent: true, AvgSize: 128,
+			WantTd: 0,
+		},
+	} {
+		calc := test.Calc
+		calc.pmembers = 1
+		calc.tp = now
+		calc.SetMembers(test.Members, test.Senders, now)
+		calc.SetWeSent(test.WeSent)
+		calc.avgRTCPSize = test.AvgSize
+		calc.initial = test.Initial
+
+		assert.InDeltaf(t, test.WantTd, calc.DeterministicInterval(), float64(time.Microsecond), "Td %q", test.Name)
+		assert.Equalf(t, test.WantTd != 0, calc.Enabled(), "Enabled %q", test.Name)
+	}
+}
+
+func TestIntervalCalculatorRandomization(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	calc := NewIntervalCalculator(64000, now)
+	calc.SetMembers(2, 2, now)
+	calc.SetWeSent(true)
+
+	// The interval is randomized in [0.5, 1.5] Td and divided by e-3/2.
+	td := float64(2500 * time.Millisecond)
+	for _, random := range []float64{0, 0.5, 1} {
+		calc.Random = constantRandom(random)
+		assert.Equal(t, time.Duration(td*(random+0.5)/rtcpCompensation), calc.Interval())
+	}
+
+	assert.Equal(t, now.Add(calc.Interval()), calc.NextTransmission())
+}
+
+func TestIntervalCalculatorAverageSize(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	calc := NewIntervalCalculator(64000, now)
+	assert.Equal(t, 92.0, calc.AverageRTCPSize())
+
+	// avg_rtcp_size = 1/16 * packet_size + 15/16 * avg_rtcp_size, with the
+	// lower-layer headers added to every packet.
+	calc.PacketReceived(100)
+	assert.Equal(t, 128.0/16+92.0*15/16, calc.AverageRTCPSize())
+
+	calc.Overhead = 48
+	calc.PacketSent(100, now)
+	assert.Equal(t, 148.0/16+(128.0/16+92.0*15/16)*15/16, calc.AverageRTCPSize())
+}
+
+func TestIntervalCalculatorTimerReconsideration(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	calc := NewIntervalCalculator(64000, now)
+	calc.Random = constantRandom(0.5)
+	calc.avgRTCPSize = 128
+
+	// With a single member the initial interval is 2.5s/(e-3/2).
+	first := calc.NextTransmission()
+	initial := float64(2500 * time.Millisecond)
+	assert.Equal(t, now.Add(time.Duration(initial/rtcpCompensation)), first)
+	assert.False(t, calc.Reconsider(first.Add(-time.Millisecond)))
+
+	// Meanwhile 1000 members joined, so the timer is reconsidered and the
+	// packet is deferred.
+	calc.SetMembers(1000, 0, now)
+	assert.False(t, calc.Reconsider(first))
+	next := calc.NextTransmission()
+	td := secondsDuration(128.0 * 1000 / 300)
+	assert.InDelta(t, time.Duration(float64(td)/rtcpCompensation), next.Sub(now), float64(time.Microsecond))
+
+	// At the rescheduled time the packet may be sent.
+	assert.True(t, calc.Reconsider(next))
+	calc.PacketSent(128-defaultRTCPOverhead, next)
+	assert.Equal(t, next, calc.LastTransmission())
+	assert.InDelta(t, time.Duration(float64(td)/rtcpCompensation), calc.NextTransmission().Sub(next), float64(time.Microsecond))
+}
+
+func TestIntervalCalculatorReverseReconsideration(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	calc := NewIntervalCalculator(64000, now)
+	calc.Random = constantRandom(0.5)
+	calc.SetMembers(100, 0, now)
+	calc.PacketSent(100, now)
+
+	tn := calc.NextTransmission()
+	tc := now.Add(10 * time.Second)
+
+	// Half of the members leave: tn and tp are moved towards tc.
+	calc.SetMembers(50, 0, tc)
+	assert.Equal(t, tc.Add(tn.Sub(tc)/2), calc.NextTransmission())
+	assert.Equal(t, tc.Add(-5*time.Second), calc.LastTransmission())
+	assert.Equal(t, 50, calc.Members())
+	assert.Equal(t, 0, calc.Senders())
+}
+
+func TestIntervalCalculatorDisabled(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	calc := NewIntervalCalculator(0, now)
+
+	assert.False(t, calc.Enabled())
+	assert.Equal(t, time.Duration(0), calc.Interval())
+	assert.False(t, calc.Reconsider(now.Add(time.Hour)))
+}
@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"slices"
+	"time"
+)
+
+// Defaults of a KeyframeRequester.
+const (
+	defaultKeyframeRetryInterval = 500 * time.Millisecond
+	defaultKeyframeMaxRetries    = 3
+)
+
+// KeyframeRequestMethod is the feedback message used to request a keyframe.
+type KeyframeRequestMethod int
+
+const (
+	// KeyframeRequestPLI requests keyframes with PictureLossIndications,
+	// RFC 4585 Section 6.3.1.
+	KeyframeRequestPLI KeyframeRequestMethod = iota
+	// KeyframeRequestFIR requests keyframes with FullIntraRequests,
+	// RFC 5104 Section 4.3.1.
+	KeyframeRequestFIR
+)
+
+func (m KeyframeRequestMethod) String() string {
+	switch m {
+	case KeyframeRequestPLI:
+		return "PLI"
+	case KeyframeRequestFIR:
+		return "FIR"
+	}
+
+	return "invalid keyframe request method"
+}
+
+type keyframeRequest struct {
+	pending  bool
+	seq      uint8
+	lastSent time.Time
+	retries  int
+}
+
+// KeyframeRequester generates the keyframe requests of a media receiver. As
+// required by RFC 5104 Section 4.3.1.1, the FIR command sequence number is
+// incremented for each new request and kept for its retransmissions, which
+// are sent every RetryInterval until a keyframe arrives or MaxRetries is
+// reached.
+//
+// The requester does not run any timers; time is passed in by the caller,
+// which should call Poll regularly.
+type KeyframeRequester struct {
+	// SSRC of the sender of the requests.
+	SenderSSRC uint32
+	// Method used to request keyframes.
+	Method KeyframeRequestMethod
+	// RetryInterval is the time after which an unanswered request is
+	// repeated, typically a little more than the round-trip time.
+	RetryInterval time.Duration
+	// MaxRetries is the number of times a request is repeated.
+	MaxRetries int
+
+	streams map[uint32]*keyframeRequest
+}
+
+// NewKeyframeRequester creates a KeyframeRequester sending requests from
+// senderSSRC with the given method.
+func NewKeyframeRequester(senderSSRC uint32, method KeyframeRequestMethod) *KeyframeRequester {
+	return &KeyframeRequester{
+		SenderSSRC:    senderSSRC,
+		Method:        method,
+		RetryInterval: defaultKeyframeRetryInterval,
+		MaxRetries:    defaultKeyframeMaxRetries,
+		streams:       map[uint32]*keyframeRequest{},
+	}
+}
+
+// Request starts a new keyframe request for mediaSSRC at time now and
+// returns the packet to send. It returns nil if a request for the stream is
+// already pending, which will be retransmitted by Poll.
+func (r *KeyframeRequester) Request(mediaSSRC uint32, now time.Time) Packet {
+	stream, ok := r.streams[mediaSSRC]
+	if !ok {
+		// The first request carries sequence number 0.
+		stream = &keyframeRequest{seq: ^uint8(0)}
+		r.streams[mediaSSRC] = stream
+	}
+	if stream.pending {
+		return nil
+	}
+
+	stream.pending = true
+	stream.seq++
+	stream.lastSent = now
+	stream.retries = 0
+
+	return r.packet([]uint32{mediaSSRC})
+}
+
+// KeyframeReceived completes the pending request for mediaSSRC, if any.
+func (r *KeyframeRequester) KeyframeReceived(mediaSSRC uint32) {
+	if stream, ok := r.streams[mediaSSRC]; ok {
+		stream.pending = false
+	}
+}
+
+// Pending reports whether a request for mediaSSRC is waiting for a keyframe.
+func (r *KeyframeRequester) Pending(mediaSSRC uint32) bool {
+	stream, ok := r.streams[mediaSSRC]
+
+	return ok && stream.pending
+}
+
+// Poll returns the retransmissions of the requests that are due at time
+// now. FullIntraRequests for several streams are combined into one packet.
+// Requests that reached MaxRetries are abandoned.
+func (r *KeyframeRequester) Poll(now time.Time) []Packet {
+	var due []uint32
+	for ssrc, stream := range r.streams {
+		if !stream.pending || now.Sub(stream.lastSent) < r.RetryInterval {
+			continue
+		}
+		if stream.retries >= r.MaxRetries {
+			stream.pending = false
+
+			continue
+		}
+		stream.retries++
+		stream.lastSent = now
+		due = append(due, ssrc)
+	}
+	if len(due) == 0 {
+		return nil
+	}
+	slices.Sort(due)
+
+	if r.Method == KeyframeRequestFIR {
+		return []Packet{r.packet(due)}
+	}
+
+	out := make([]Packet, 0, len(due))
+	for _, ssrc := range due {
+		out = append(out, r.packet([]uint32{ssrc}))
+	}
+
+	return out
+}
+
+// NextRetry returns the time of the next retransmission, or the zero time if
+// no request is pending.
+func (r *KeyframeRequester) NextRetry() time.Time {
+	var next time.Time
+	for _, stream := range r.streams {
+		if !stream.pending {
+			continue
+		}
+		if t := stream.lastSent.Add(r.RetryInterval); next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+
+	return next
+}
+
+func (r *KeyframeRequester) packet(mediaSSRCs []uint32) Packet {
+	if r.Method == KeyframeRequestFIR {
+		// The media source SSRC of a FIR is unused and set to 0, the
+		// streams are identified by the FCI entries.
+		fir := &FullIntraRequest{SenderSSRC: r.SenderSSRC}
+		for _, ssrc := range mediaSSRCs {
+			fir.FIR = append(fir.FIR, FIREntry{SSRC: ssrc, SequenceNumber: r.streams[ssrc].seq})
+		}
+
+		return fir
+	}
+
+	return &PictureLossIndication{SenderSSRC: r.SenderSSRC, MediaSSRC: mediaSSRCs[0]}
+}
+
+// KeyframeRequestFilter deduplicates the keyframe requests received by a
+// media sender. A FullIntraRequest repeating the sequence number of the last
+// request of the same requester is a retransmission and is ignored, as
+// required by RFC 5104 Section 4.3.1.2. Requests for a stream arriving less
+// than MinInterval after the last accepted one are dropped, which throttles
+// PLI storms from many receivers.
+type KeyframeRequestFilter struct {
+	// MinInterval is the minimum time between two keyframe requests
+	// accepted for the same media source.
+	MinInterval time.Duration
+
+	lastAccepted map[uint32]time.Time
+	firSeq       map[feedbackKey]uint8
+}
+
+// NewKeyframeRequestFilter creates a KeyframeRequestFilter with the given
+// minimum interval.
+func NewKeyframeRequestFilter(minInterval time.Duration) *KeyframeRequestFilter {
+	return &KeyframeRequestFilter{
+		MinInterval:  minInterval,
+		lastAccepted: map[uint32]time.Time{},
+		firSeq:       map[feedbackKey]uint8{},
+	}
+}
+
+// Filter processes the packets pkts received at time now, as returned by
+// Unmarshal, and returns the SSRCs of the media sources for which a
+// keyframe should be sent, in ascending order.
+func (f *KeyframeRequestFilter) Filter(pkts []Packet, now time.Time) []uint32 {
+	// The FIR sequence numbers of each media source are only recorded once
+	// its request is accepted, so that the retransmissions of a throttled
+	// FIR are not taken for duplicates.
+	requested := map[uint32]map[feedbackKey]uint8{}
+	for _, pkt := range flattenPackets(pkts) {
+		switch p := pkt.(type) {
+		case *PictureLossIndication:
+			if _, ok := requested[p.MediaSSRC]; !ok {
+				requested[p.MediaSSRC] = map[feedbackKey]uint8{}
+			}
+		case *FullIntraRequest:
+			for _, entry := range p.FIR {
+				key := feedbackKey{sender: p.SenderSSRC, media: entry.SSRC}
+				if seq, ok := f.firSeq[key]; ok && seq == entry.SequenceNumber {
+					continue
+				}
+				if _, ok := requested[entry.SSRC]; !ok {
+					requested[entry.SSRC] = map[feedbackKey]uint8{}
+				}
+				requested[entry.SSRC][key] = entry.SequenceNumber
+			}
+		}
+	}
+
+	var out []uint32
+	for ssrc, firSeqs := range requested {
+		if last, ok := f.lastAccepted[ssrc]; ok && now.Sub(last) < f.MinInterval {
+			continue
+		}
+		f.lastAccepted[ssrc] = now
+		for key, seq := range firSeqs {
+			f.firSeq[key] = seq
+		}
+		out = append(out, ssrc)
+	}
+	slices.Sort(out)
+
+	return out
+}
+
+// Forget drops the state kept for the media source ssrc, for example when
+// the stream ends.
+func (f *KeyframeRequestFilter) Forget(ssrc uint32) {
+	delete(f.lastAccepted, ssrc)
+	for key := range f.firSeq {
+		if key.media == ssrc {
+			delete(f.firSeq, key)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyframeRequesterFIR(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	requester := NewKeyframeRequester(0x1, KeyframeRequestFIR)
+	requester.RetryInterval = 100 * time.Millisecond
+	requester.MaxRetries = 2
+
+	assert.Equal(t, &FullIntraRequest{SenderSSRC: 0x1, FIR: []FIREntry{{SSRC: 0xA, SequenceNumber: 0}}},
+		requester.Request(0xA, now))
+	assert.Nil(t, requester.Request(0xA, now), "a request is already pending")
+	assert.True(t, requester.Pending(0xA))
+	assert.Equal(t, now.Add(100*time.Millisecond), requester.NextRetry())
+
+	now = now.Add(50 * time.Millisecond)
+	assert.Equal(t, &FullIntraRequest{SenderSSRC: 0x1, FIR: []FIREntry{{SSRC: 0xB, SequenceNumber: 0}}},
+		requester.Request(0xB, now))
+	assert.Nil(t, requester.Poll(now))
+
+	// Retransmissions keep the sequence number.
+	now = now.Add(50 * time.Millisecond)
+	assert.Equal(t, []Packet{&FullIntraRequest{SenderSSRC: 0x1, FIR: []FIREntry{{SSRC: 0xA, SequenceNumber: 0}}}},
+		requester.Poll(now))
+	now = now.Add(50 * time.Millisecond)
+	assert.Equal(t, []Packet{&FullIntraRequest{SenderSSRC: 0x1, FIR: []FIREntry{{SSRC: 0xB, SequenceNumber: 0}}}},
+		requester.Poll(now))
+	now = now.Add(50 * time.Millisecond)
+	requester.KeyframeReceived(0xB)
+	assert.Equal(t, []Packet{&FullIntraRequest{SenderSSRC: 0x1, FIR: []FIREntry{{SSRC: 0xA, SequenceNumber: 0}}}},
+		requester.Poll(now))
+
+	// The request for 0xA is abandoned after two retries.
+	now = now.Add(100 * time.Millisecond)
+	assert.Nil(t, requester.Poll(now))
+	assert.False(t, requester.Pending(0xA))
+	assert.True(t, requester.NextRetry().IsZero())
+
+	// New requests increment the sequence number, and retransmissions for
+	// several streams are combined.
+	assert.Equal(t, &FullIntraRequest{SenderSSRC: 0x1, FIR: []FIREntry{{SSRC: 0xA, SequenceNumber: 1}}},
+		requester.Request(0xA, now))
+	assert.Equal(t, &FullIntraRequest{SenderSSRC: 0x1, FIR: []FIREntry{{SSRC: 0xB, SequenceNumber: 1}}},
+		requester.Request(0xB, now))
+	now = now.Add(100 * time.Millisecond)
+	assert.Equal(t, []Packet{&FullIntraRequest{SenderSSRC: 0x1, FIR: []FIREntry{
+		{SSRC: 0xA, SequenceNumber: 1},
+		{SSRC: 0xB, SequenceNumber: 1},
+	}}}, requester.Poll(now))
+}
+
+func TestKeyframeRequesterFIRSequenceWraps(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	requester := NewKeyframeRequester(0x1, KeyframeRequestFIR)
+
+	var fir *FullIntraRequest
+	for i := 0; i < 257; i++ {
+		pkt, ok := requester.Request(0xA, now).(*FullIntraRequest)
+		assert.True(t, ok)
+		fir = pkt
+		requester.KeyframeReceived(0xA)
+	}
+	assert.Equal(t, uint8(0), fir.FIR[0].SequenceNumber)
+}
+
+func TestKeyframeRequesterPLI(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	requester := NewKeyframeRequester(0x1, KeyframeRequestPLI)
+
+	assert.Equal(t, &PictureLossIndication{SenderSSRC: 0x1, MediaSSRC: 0xA}, requester.Request(0xA, now))
+	assert.Equal(t, &PictureLossIndication{SenderSSRC: 0x1, MediaSSRC: 0xB}, requester.Request(0xB, now))
+
+	now = now.Add(defaultKeyframeRetryInterval)
+	assert.Equal(t, []Packet{
+		&PictureLossIndication{SenderSSRC: 0x1, MediaSSRC: 0xA},
+		&PictureLossIndication{SenderSSRC: 0x1, MediaSSRC: 0xB},
+	}, requester.Poll(now))
+	assert.Equal(t, "PLI", KeyframeRequestPLI.String())
+}
+
+func TestKeyframeRequestFilter(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	filter := NewKeyframeRequestFilter(time.Second)
+
+	// A PLI storm from several subscribers results in one keyframe.
+	assert.Equal(t, []uint32{0xA}, filter.Filter([]Packet{
+		&PictureLossIndication{SenderSSRC: 0x1, MediaSSRC: 0xA},
+		&PictureLossIndication{SenderSSRC: 0x2, MediaSSRC: 0xA},
+		&CompoundPacket{
+			&ReceiverReport{SSRC: 0x3},
+			&PictureLossIndication{SenderSSRC: 0x3, MediaSSRC: 0xA},
+		},
+	}, now))
+	assert.Empty(t, filter.Filter([]Packet{&PictureLossIndication{SenderSSRC: 0x1, MediaSSRC: 0xA}}, now.Add(500*time.Millisecond)))
+
+	// Other streams are throttled independently.
+	assert.Equal(t, []uint32{0xB}, filter.Filter([]Packet{
+		&FullIntraRequest{SenderSSRC: 0x1, FIR: []FIREntry{{SSRC: 0xB, SequenceNumber: 7}}},
+	}, now.Add(500*time.Millisecond)))
+
+	now = now.Add(2 * time.Second)
+	// A retransmitted FIR is ignored, a new sequence number is not, and
+	// neither is a FIR with the same sequence number from another requester.
+	assert.Empty(t, filter.Filter([]Packet{
+		&FullIntraRequest{SenderSSRC: 0x1, FIR: []FIREntry{{SSRC: 0xB, SequenceNumber: 7}}},
+	}, now))
+	assert.Equal(t, []uint32{0xA, 0xB}, filter.Filter([]Packet{
+		&FullIntraRequest{SenderSSRC: 0x2, FIR: []FIREntry{{SSRC: 0xB, SequenceNumber: 7}}},
+		&PictureLossIndication{SenderSSRC: 0x1, MediaSSRC: 0xA},
+	}, now))
+
+	now = now.Add(2 * time.Second)
+	assert.Equal(t, []uint32{0xB}, filter.Filter([]Packet{
+		&FullIntraRequest{SenderSSRC: 0x1, FIR: []FIREntry{{SSRC: 0xB, SequenceNumber: 8}}},
+	}, now))
+
+	filter.Forget(0xB)
+	assert.Equal(t, []uint32{0xB}, filter.Filter([]Packet{
+		&FullIntraRequest{SenderSSRC: 0x1, FIR: []FIREntry{{SSRC: 0xB, SequenceNumber: 8}}},
+	}, now))
+}
+
+func TestKeyframeRequestFilterThrottledFIR(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	filter := NewKeyframeRequestFilter(time.Second)
+	assert.Equal(t, []uint32{0xA}, filter.Filter([]Packet{
+		&PictureLossIndication{SenderSSRC: 0x2, MediaSSRC: 0xA},
+	}, now))
+
+	// A new FIR dropped by the throttling is accepted when retransmitted
+	// after MinInterval.
+	fir := &FullIntraRequest{SenderSSRC: 0x1, FIR: []FIREntry{{SSRC: 0xA, SequenceNumber: 3}}}
+	assert.Empty(t, filter.Filter([]Packet{fir}, now.Add(500*time.Millisecond)))
+	assert.Equal(t, []uint32{0xA}, filter.Filter([]Packet{fir}, now.Add(1500*time.Millisecond)))
+	assert.Empty(t, filter.Filter([]Packet{fir}, now.Add(3*time.Second)))
+}
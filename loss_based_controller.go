@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"time"
+)
+
+// Parameters of the loss-based controller, draft-ietf-rmcat-gcc-02
+// Section 6, and the update intervals of libwebrtc.
+const (
+	lossLowThreshold      = 0.02
+	lossHighThreshold     = 0.1
+	lossIncreaseFactor    = 1.05
+	lossIncreaseInterval  = time.Second
+	lossDecreaseInterval  = 300 * time.Millisecond
+	defaultLossMinBitrate = 30000
+	defaultLossMaxBitrate = 10000000
+	defaultStaleTimeout   = 5 * time.Second
+)
+
+type lossReceiver struct {
+	lastSequenceNumber uint32
+	fractionLost       uint8
+	updated            time.Time
+}
+
+// LossBasedController is the loss-based controller of Google Congestion
+// Control, draft-ietf-rmcat-gcc-02 Section 6, driven by the reception
+// reports about the stream SSRC.
+//
+// The target bitrate increases by 5% when the fraction lost is below 2%,
+// is held between 2% and 10%, and decreases by half the fraction lost above
+// 10%. With several receivers, the fraction lost is the highest reported by
+// the receivers that sent a report within StaleTimeout, so that the target
+// suits all of them. Increases are applied at most once per second and
+// decreases once per 300ms, as receivers report at different times.
+//
+// Reports whose extended highest sequence number does not advance are
+// stale or duplicates, and are ignored. The lowest of the limits requested
+// by each receiver through REMB or TMMBR bounds the target bitrate, until
+// the receiver leaves with a Goodbye.
+type LossBasedController struct {
+	// SSRC of the controlled stream.
+	SSRC uint32
+	// MinBitrate and MaxBitrate bound the target bitrate, in bits per second.
+	MinBitrate, MaxBitrate uint64
+	// StaleTimeout is the time after which the reports of a receiver are no
+	// longer taken into account.
+	StaleTimeout time.Duration
+
+	bitrate      float64
+	receivers    map[uint32]*lossReceiver
+	rembLimits   map[uint32]uint64
+	tmmbrLimits  map[uint32]uint64
+	lastIncrease time.Time
+	lastDecrease time.Time
+}
+
+// NewLossBasedController creates a LossBasedController for the stream ssrc,
+// starting at initialBitrate bits per second.
+func NewLossBasedController(ssrc uint32, initialBitrate uint64) *LossBasedController {
+	return &LossBasedController{
+		SSRC:         ssrc,
+		MinBitrate:   defaultLossMinBitrate,
+		MaxBitrate:   defaultLossMaxBitrate,
+		StaleTimeout: defaultStaleTimeout,
+		bitrate:      float64(initialBitrate),
+		receivers:    map[uint32]*lossReceiver{},
+		rembLimits:   map[uint32]uint64{},
+		tmmbrLimits:  map[uint32]uint64{},
+	}
+}
+
+// Receive processes the packets received at time now and returns the
+// target bitrate, in bits per second.
+func (c *LossBasedController) Receive(pkts []Packet, now time.Time) uint64 {
+	updated := false
+	for _, pkt := range flattenPackets(pkts) {
+		switch pkt := pkt.(type) {
+		case *SenderReport:
+			updated = c.receiveReports(pkt.SSRC, pkt.Reports, now) || updated
+		case *ReceiverReport:
+			updated = c.receiveReports(pkt.SSRC, pkt.Reports, now) || updated
+		case *ReceiverEstimatedMaximumBitrate:
+			for _, ssrc := range pkt.SSRCs {
+				if ssrc == c.SSRC {
+					c.rembLimits[pkt.SenderSSRC] = uint64(pkt.Bitrate)
+				}
+			}
+		case *TMMBR:
+			for _, entry := range pkt.Entries {
+				if entry.MediaSSRC == c.SSRC {
+					c.tmmbrLimits[pkt.SenderSSRC] = entry.Bitrate
+				}
+			}
+		case *Goodbye:
+			for _, ssrc := range pkt.Sources {
+				delete(c.receivers, ssrc)
+				delete(c.rembLimits, ssrc)
+				delete(c.tmmbrLimits, ssrc)
+			}
+		}
+	}
+
+	if updated {
+		c.update(now)
+	}
+	c.bitrate = min(max(c.bitrate, float64(c.MinBitrate)), float64(c.upperBound()))
+
+	return c.TargetBitrate()
+}
+
+func (c *LossBasedController) receiveReports(reporter uint32, reports []ReceptionReport, now time.Time) bool {
+	updated := false
+	for _, report := range reports {
+		if report.SSRC != c.SSRC {
+			continue
+		}
+		receiver, ok := c.receivers[reporter]
+		if ok && int32(report.LastSequenceNumber-receiver.lastSequenceNumber) <= 0 { //nolint:gosec // G115
+			continue
+		}
+		c.receivers[reporter] = &lossReceiver{
+			lastSequenceNumber: report.LastSequenceNumber,
+			fractionLost:       report.FractionLost,
+			updated:            now,
+		}
+		updated = true
+	}
+
+	return updated
+}
+
+func (c *LossBasedController) update(now time.Time) {
+	loss, ok := c.FractionLost(now)
+	if !ok {
+		return
+	}
+
+	switch {
+	case loss < lossLowThreshold:
+		if now.Sub(c.lastIncrease) >= lossIncreaseInterval {
+			c.bitrate *= lossIncreaseFactor
+			c.lastIncrease = now
+		}
+	case loss > lossHighThreshold:
+		if now.Sub(c.lastDecrease) >= lossDecreaseInterval {
+			c.bitrate *= 1 - loss/2
+			c.lastDecrease = now
+		}
+	}
+}
+
+// FractionLost returns the highest fraction lost reported by the receivers
+// that reported within StaleTimeout of now.
+func (c *LossBasedController) FractionLost(now time.Time) (float64, bool) {
+	var fractionLost uint8
+	ok := false
+	for _, receiver := range c.receivers {
+		if now.Sub(receiver.updated) > c.StaleTimeout {
+			continue
+		}
+		fractionLost = max(fractionLost, receiver.fractionLost)
+		ok = true
+	}
+
+	return float64(fractionLost) / 256, ok
+}
+
+// Limit returns the lowest limit requested by the receivers through REMB
+// or TMMBR, in bits per second.
+func (c *LossBasedController) Limit() (uint64, bool) {
+	var limit uint64
+	ok := false
+	for _, limits := range []map[uint32]uint64{c.rembLimits, c.tmmbrLimits} {
+		for _, bitrate := range limits {
+			if !ok || bitrate < limit {
+				limit = bitrate
+				ok = true
+			}
+		}
+	}
+
+	return limit, ok
+}
+
+func (c *LossBasedController) upperBound() uint64 {
+	if limit, ok := c.Limit(); ok {
+		return min(limit, c.MaxBitrate)
+	}
+
+	return c.MaxBitrate
+}
+
+// TargetBitrate returns the current target bitrate, in bits per second.
+func (c *LossBasedController) TargetBitrate() uint64 {
+	return uint64(c.bitrate)
+}
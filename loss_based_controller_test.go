@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func lossReport(reporter, ssrc uint32, seq uint32, fractionLost uint8) *ReceiverReport {
+	return &ReceiverReport{SSRC: reporter, Reports: []ReceptionReport{
+		{SSRC: ssrc, LastSequenceNumber: seq, FractionLost: fractionLost},
+	}}
+}
+
+func TestLossBasedController(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	controller := NewLossBasedController(0xA, 1000000)
+
+	// Below 2% the bitrate increases by 5%, at most once per second.
+	assert.Equal(t, uint64(1050000), controller.Receive([]Packet{lossReport(0x1, 0xA, 100, 4)}, now))
+	assert.Equal(t, uint64(1050000), controller.Receive([]Packet{lossReport(0x1, 0xA, 200, 0)}, now.Add(500*time.Millisecond)))
+	now = now.Add(time.Second)
+	assert.Equal(t, uint64(1102500), controller.Receive([]Packet{lossReport(0x1, 0xA, 300, 0)}, now))
+
+	// Between 2% and 10% the bitrate is held.
+	now = now.Add(time.Second)
+	assert.Equal(t, uint64(1102500), controller.Receive([]Packet{lossReport(0x1, 0xA, 400, 12)}, now))
+
+	// Above 10% the bitrate decreases by half the fraction lost.
+	now = now.Add(time.Second)
+	assert.Equal(t, uint64(826875), controller.Receive([]Packet{lossReport(0x1, 0xA, 500, 128)}, now))
+	assert.Equal(t, uint64(826875), controller.Receive([]Packet{lossReport(0x1, 0xA, 600, 128)}, now.Add(100*time.Millisecond)))
+
+	// Reports about other streams are ignored.
+	now = now.Add(time.Second)
+	assert.Equal(t, uint64(826875), controller.Receive([]Packet{lossReport(0x1, 0xB, 700, 0)}, now))
+}
+
+func TestLossBasedControllerStaleReports(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	controller := NewLossBasedController(0xA, 1000000)
+	controller.Receive([]Packet{lossReport(0x1, 0xA, 100, 0)}, now)
+
+	// Reports whose highest sequence number does not advance are ignored,
+	// including across the wrap of the extended sequence number.
+	now = now.Add(time.Second)
+	assert.Equal(t, uint64(1050000), controller.Receive([]Packet{lossReport(0x1, 0xA, 100, 128)}, now))
+	assert.Equal(t, uint64(1050000), controller.Receive([]Packet{lossReport(0x1, 0xA, 50, 128)}, now))
+
+	controller = NewLossBasedController(0xA, 1000000)
+	controller.Receive([]Packet{lossReport(0x1, 0xA, 0xFFFFFFF0, 0)}, now)
+	now = now.Add(time.Second)
+	assert.Equal(t, uint64(1102500), controller.Receive([]Packet{lossReport(0x1, 0xA, 0x10, 0)}, now))
+
+	// Receivers that stopped reporting are not taken into account.
+	loss, ok := controller.FractionLost(now.Add(5 * time.Second))
+	assert.True(t, ok)
+	assert.Zero(t, loss)
+	_, ok = controller.FractionLost(now.Add(6 * time.Second))
+	assert.False(t, ok)
+}
+
+func TestLossBasedControllerReceivers(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	controller := NewLossBasedController(0xA, 1000000)
+
+	// The highest fraction lost of the receivers is used.
+	controller.Receive([]Packet{
+		&CompoundPacket{lossReport(0x1, 0xA, 100, 0)},
+		&SenderReport{SSRC: 0x2, Reports: []ReceptionReport{{SSRC: 0xA, LastSequenceNumber: 100, FractionLost: 64}}},
+	}, now)
+	assert.Equal(t, uint64(875000), controller.TargetBitrate())
+	loss, ok := controller.FractionLost(now)
+	assert.True(t, ok)
+	assert.Equal(t, 0.25, loss)
+
+	// A receiver leaving no longer holds the bitrate down.
+	now = now.Add(time.Second)
+	assert.Equal(t, uint64(918750), controller.Receive([]Packet{
+		&Goodbye{Sources: []uint32{0x2}},
+		lossReport(0x1, 0xA, 200, 0),
+	}, now))
+}
+
+func TestLossBasedControllerLimits(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	controller := NewLossBasedController(0xA, 1000000)
+	_, ok := controller.Limit()
+	assert.False(t, ok)
+
+	// The lowest REMB or TMMBR limit about the stream bounds the bitrate.
+	assert.Equal(t, uint64(800000), controller.Receive([]Packet{
+		&ReceiverEstimatedMaximumBitrate{SenderSSRC: 0x1, Bitrate: 900000, SSRCs: []uint32{0xA}},
+		&ReceiverEstimatedMaximumBitrate{SenderSSRC: 0x2, Bitrate: 100000, SSRCs: []uint32{0xB}},
+		&TMMBR{SenderSSRC: 0x2, Entries: []TMMBREntry{{MediaSSRC: 0xA, Bitrate: 800000}}},
+	}, now))
+	limit, ok := controller.Limit()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(800000), limit)
+
+	// The bitrate does not increase beyond the limit.
+	for seq := uint32(1); seq <= 5; seq++ {
+		now = now.Add(time.Second)
+		assert.Equal(t, uint64(800000), controller.Receive([]Packet{lossReport(0x1, 0xA, seq, 0)}, now))
+	}
+
+	// The limit is raised by a new request of the receiver.
+	now = now.Add(time.Second)
+	assert.Equal(t, uint64(840000), controller.Receive([]Packet{
+		&TMMBR{SenderSSRC: 0x2, Entries: []TMMBREntry{{MediaSSRC: 0xA, Bitrate: 2000000}}},
+		lossReport(0x1, 0xA, 10, 0),
+	}, now))
+}
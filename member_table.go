@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Timeout multipliers of RFC 3550 Section 6.3.5.
+const (
+	// A sender that has not sent RTP for this many report intervals
+	// becomes a receiver.
+	senderTimeoutIntervals = 2
+	// A member that has not been heard from for this many deterministic
+	// report intervals is removed, the value M.
+	memberTimeoutIntervals = 5
+)
+
+// MemberEventType is the kind of a MemberEvent.
+type MemberEventType int
+
+const (
+	// MemberJoined is raised when a new SSRC is heard from.
+	MemberJoined MemberEventType = iota
+	// MemberLeft is raised when a member sent a Goodbye.
+	MemberLeft
+	// MemberTimedOut is raised when a member was not heard from for too long.
+	MemberTimedOut
+)
+
+func (t MemberEventType) String() string {
+	switch t {
+	case MemberJoined:
+		return "joined"
+	case MemberLeft:
+		return "left"
+	case MemberTimedOut:
+		return "timed out"
+	}
+
+	return "invalid member event"
+}
+
+// MemberEvent is a change in session membership reported by a MemberTable.
+type MemberEvent struct {
+	Type MemberEventType
+	// SSRC of the member.
+	SSRC uint32
+	// CNAME of the member, if known.
+	CNAME string
+	// Reason given in the Goodbye, for MemberLeft.
+	Reason string
+}
+
+// Member is a participant of an RTP session as tracked by a MemberTable.
+type Member struct {
+	SSRC uint32
+	// CNAME and the other SDES items last announced by the member.
+	CNAME string
+	Items map[SDESType]string
+	// Sender is set while the member is an active sender.
+	Sender bool
+	// LastHeard is when an RTP or RTCP packet of the member was last received.
+	LastHeard time.Time
+	// LastSent is when RTP or a SenderReport of the member was last received.
+	LastSent time.Time
+	// LastSenderReport is the last SenderReport received from the member,
+	// without its reception report blocks, and LastSenderReportTime its
+	// time of arrival.
+	LastSenderReport     *SenderReport
+	LastSenderReportTime time.Time
+}
+
+// MemberTable tracks the members of an RTP session from the RTP and RTCP
+// packets received, following RFC 3550 Section 6.3. Our own SSRC is not
+// part of the table, so the group size used for RTCP timing is Len() + 1.
+type MemberTable struct {
+	// LocalSSRC is our own SSRC. Packets carrying it are ignored.
+	LocalSSRC uint32
+
+	members map[uint32]*Member
+}
+
+// NewMemberTable creates an empty MemberTable.
+func NewMemberTable(localSSRC uint32) *MemberTable {
+	return &MemberTable{LocalSSRC: localSSRC, members: map[uint32]*Member{}}
+}
+
+// Len returns the number of members, not counting ourselves.
+func (t *MemberTable) Len() int {
+	return len(t.members)
+}
+
+// SenderCount returns the number of members that are active senders, not
+// counting ourselves.
+func (t *MemberTable) SenderCount() int {
+	n := 0
+	for _, m := range t.members {
+		if m.Sender {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Member returns the member with the given SSRC.
+func (t *MemberTable) Member(ssrc uint32) (*Member, bool) {
+	m, ok := t.members[ssrc]
+
+	return m, ok
+}
+
+// Members returns all members ordered by SSRC.
+func (t *MemberTable) Members() []*Member {
+	out := make([]*Member, 0, len(t.members))
+	for _, m := range t.members {
+		out = append(out, m)
+	}
+	slices.SortFunc(out, func(a, b *Member) int {
+		return cmp.Compare(a.SSRC, b.SSRC)
+	})
+
+	return out
+}
+
+// ReceiveRTP records an RTP packet received from ssrc at time now, which
+// makes it an active sender.
+func (t *MemberTable) ReceiveRTP(ssrc uint32, now time.Time) []MemberEvent {
+	if ssrc == t.LocalSSRC {
+		return nil
+	}
+
+	m, events := t.lookup(ssrc, now, nil)
+	m.Sender = true
+	m.LastSent = now
+
+	return events
+}
+
+// Receive updates the table from RTCP packets received at time now, as
+// returned by Unmarshal. SenderReports, ReceiverReports, SourceDescriptions
+// and Goodbyes are taken into account, other packets are ignored.
+func (t *MemberTable) Receive(pkts []Packet, now time.Time) []MemberEvent {
+	var events []MemberEvent
+	for _, pkt := range pkts {
+		events = t.receive(pkt, now, events)
+	}
+
+	// Members usually join with a report followed by their CNAME.
+	for i, event := range events {
+		if m, ok := t.members[event.SSRC]; ok && event.Type == MemberJoined {
+			events[i].CNAME = m.CNAME
+		}
+	}
+
+	return events
+}
+
+func (t *MemberTable) receive(pkt Packet, now time.Time, events []MemberEvent) []MemberEvent {
+	switch p := pkt.(type) {
+	case *CompoundPacket:
+		for _, inner := range *p {
+			events = t.receive(inner, now, events)
+		}
+	case *SenderReport:
+		if p.SSRC == t.LocalSSRC {
+			break
+		}
+		var m *Member
+		m, events = t.lookup(p.SSRC, now, events)
+		m.Sender = true
+		m.LastSent = now
+		m.LastSenderReport = &SenderReport{
+			SSRC:        p.SSRC,
+			NTPTime:     p.NTPTime,
+			RTPTime:     p.RTPTime,
+			PacketCount: p.PacketCount,
+			OctetCount:  p.OctetCount,
+		}
+		m.LastSenderReportTime = now
+	case *ReceiverReport:
+		if p.SSRC == t.LocalSSRC {
+			break
+		}
+		_, events = t.lookup(p.SSRC, now, events)
+	case *SourceDescription:
+		for _, chunk := range p.Chunks {
+			if chunk.Source == t.LocalSSRC {
+				continue
+			}
+			var m *Member
+			m, events = t.lookup(chunk.Source, now, events)
+			for _, item := range chunk.Items {
+				if item.Type == SDESCNAME {
+					m.CNAME = item.Text
+				}
+				if m.Items == nil {
+					m.Items = map[SDESType]string{}
+				}
+				m.Items[item.Type] = item.Text
+			}
+		}
+	case *Goodbye:
+		for _, ssrc := range p.Sources {
+			m, ok := t.members[ssrc]
+			if !ok {
+				continue
+			}
+			delete(t.members, ssrc)
+			events = append(events, MemberEvent{Type: MemberLeft, SSRC: ssrc, CNAME: m.CNAME, Reason: p.Reason})
+		}
+	}
+
+	return events
+}
+
+// Timeout applies the timeouts of RFC 3550 Section 6.3.5 at time now, and
+// should be called whenever the RTCP transmission timer expires. td is the
+// deterministic report interval, see IntervalCalculator. Senders that have
+// not sent RTP in the last two intervals become receivers, and members that
+// have not been heard from in the last five intervals are removed.
+func (t *MemberTable) Timeout(now time.Time, td time.Duration) []MemberEvent {
+	var events []MemberEvent
+	for _, m := range t.Members() {
+		if m.Sender && now.Sub(m.LastSent) > senderTimeoutIntervals*td {
+			m.Sender = false
+		}
+		if now.Sub(m.LastHeard) > memberTimeoutIntervals*td {
+			delete(t.members, m.SSRC)
+			events = append(events, MemberEvent{Type: MemberTimedOut, SSRC: m.SSRC, CNAME: m.CNAME})
+		}
+	}
+
+	return events
+}
+
+func (t *MemberTable) lookup(ssrc uint32, now time.Time, events []MemberEvent) (*Member, []MemberEvent) {
+	m, ok := t.members[ssrc]
+	if !ok {
+		m = &Member{SSRC: ssrc}
+		t.members[ssrc] = m
+		events = append(events, MemberEvent{Type: MemberJoined, SSRC: ssrc})
+	}
+	m.LastHeard = now
+
+	return m, events
+}
@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemberTableJoin(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	table := NewMemberTable(0x1)
+
+	events := table.Receive([]Packet{
+		&SenderReport{SSRC: 0xA, NTPTime: 0xda8bd1fcdddda05a, RTPTime: 1234, Reports: []ReceptionReport{{SSRC: 0x1}}},
+		&SourceDescription{Chunks: []SourceDescriptionChunk{{
+			Source: 0xA,
+			Items: []SourceDescriptionItem{
+				{Type: SDESCNAME, Text: "alice@example.com"},
+				{Type: SDESTool, Text: "pion"},
+			},
+		}}},
+	}, now)
+	assert.Equal(t, []MemberEvent{{Type: MemberJoined, SSRC: 0xA, CNAME: "alice@example.com"}}, events)
+
+	events = table.Receive([]Packet{
+		&CompoundPacket{
+			&ReceiverReport{SSRC: 0xB},
+			NewCNAMESourceDescription(0xB, "bob@example.com"),
+		},
+		// Our own packets looped back are ignored.
+		&ReceiverReport{SSRC: 0x1},
+		NewCNAMESourceDescription(0x1, "me@example.com"),
+		// Other packets are ignored.
+		&PictureLossIndication{SenderSSRC: 0xC, MediaSSRC: 0x1},
+	}, now)
+	assert.Equal(t, []MemberEvent{{Type: MemberJoined, SSRC: 0xB, CNAME: "bob@example.com"}}, events)
+
+	events = table.ReceiveRTP(0xC, now)
+	assert.Equal(t, []MemberEvent{{Type: MemberJoined, SSRC: 0xC}}, events)
+	assert.Empty(t, table.ReceiveRTP(0xC, now))
+	assert.Empty(t, table.ReceiveRTP(0x1, now))
+
+	assert.Equal(t, 3, table.Len())
+	assert.Equal(t, 2, table.SenderCount())
+
+	alice, ok := table.Member(0xA)
+	assert.True(t, ok)
+	assert.Equal(t, &Member{
+		SSRC:  0xA,
+		CNAME: "alice@example.com",
+		Items: map[SDESType]string{
+			SDESCNAME: "alice@example.com",
+			SDESTool:  "pion",
+		},
+		Sender:               true,
+		LastHeard:            now,
+		LastSent:             now,
+		LastSenderReport:     &SenderReport{SSRC: 0xA, NTPTime: 0xda8bd1fcdddda05a, RTPTime: 1234},
+		LastSenderReportTime: now,
+	}, alice)
+
+	bob, ok := table.Member(0xB)
+	assert.True(t, ok)
+	assert.False(t, bob.Sender)
+
+	members := table.Members()
+	assert.Len(t, members, 3)
+	assert.Equal(t, []uint32{0xA, 0xB, 0xC}, []uint32{members[0].SSRC, members[1].SSRC, members[2].SSRC})
+}
+
+func TestMemberTableGoodbye(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	table := NewMemberTable(0x1)
+
+	table.Receive([]Packet{NewCNAMESourceDescription(0xA, "alice@example.com")}, now)
+	table.ReceiveRTP(0xB, now)
+
+	events := table.Receive([]Packet{&Goodbye{Sources: []uint32{0xA, 0xB, 0xD}, Reason: "bye"}}, now)
+	assert.Equal(t, []MemberEvent{
+		{Type: MemberLeft, SSRC: 0xA, CNAME: "alice@example.com", Reason: "bye"},
+		{Type: MemberLeft, SSRC: 0xB, Reason: "bye"},
+	}, events)
+	assert.Equal(t, 0, table.Len())
+}
+
+func TestMemberTableTimeout(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	td := 5 * time.Second
+	table := NewMemberTable(0x1)
+
+	table.ReceiveRTP(0xA, now)
+	table.Receive([]Packet{&ReceiverReport{SSRC: 0xB}}, now)
+
+	// Still heard from by RTCP but no RTP for more than 2 intervals.
+	table.Receive([]Packet{&ReceiverReport{SSRC: 0xA}}, now.Add(11*time.Second))
+	assert.Empty(t, table.Timeout(now.Add(11*time.Second), td))
+	member, _ := table.Member(0xA)
+	assert.False(t, member.Sender)
+	assert.Equal(t, 0, table.SenderCount())
+
+	// Not heard from at all for more than 5 intervals.
+	assert.Empty(t, table.Timeout(now.Add(25*time.Second), td))
+	events := table.Timeout(now.Add(26*time.Second), td)
+	assert.Equal(t, []MemberEvent{{Type: MemberTimedOut, SSRC: 0xB}}, events)
+	assert.Equal(t, 1, table.Len())
+}
+
+func TestMemberEventTypeString(t *testing.T) {
+	assert.Equal(t, "joined", MemberJoined.String())
+	assert.Equal(t, "left", MemberLeft.String())
+	assert.Equal(t, "timed out", MemberTimedOut.String())
+	assert.Equal(t, "invalid member event", MemberEventType(42).String())
+}
@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Defaults of a NackGenerator.
+const (
+	defaultNackRTT         = 100 * time.Millisecond
+	defaultNackMaxRetries  = 10
+	defaultNackMaxAge      = time.Second
+	defaultNackMaxListSize = 250
+)
+
+type missingPacket struct {
+	detected time.Time
+	lastSent time.Time
+	retries  int
+}
+
+// NackGenerator tracks the sequence numbers received on one RTP stream and
+// generates the TransportLayerNacks requesting the missing packets.
+//
+// A missing packet is requested once ReorderTolerance newer packets have
+// been received, to leave room for reordering, and then again every RTT
+// until it is received, it has been requested MaxRetries times or it is
+// older than MaxAge. At most MaxListSize missing packets are tracked; the
+// oldest ones are given up first.
+//
+// The generator does not run any timers; time is passed in by the caller,
+// which should call Poll regularly.
+type NackGenerator struct {
+	// SSRC of the sender of the NACKs, and of the media source.
+	SenderSSRC uint32
+	MediaSSRC  uint32
+	// ReorderTolerance is the number of packets newer than a missing one
+	// that must be received before it is requested.
+	ReorderTolerance uint16
+	// RTT is the round-trip time, used as the interval between requests
+	// for the same packet.
+	RTT time.Duration
+	// MaxRetries is the maximum number of times a packet is requested.
+	MaxRetries int
+	// MaxAge is the time after which a missing packet is given up.
+	MaxAge time.Duration
+	// MaxListSize is the maximum number of missing packets tracked.
+	MaxListSize int
+
+	initialized bool
+	highest     uint16
+	missing     map[uint16]*missingPacket
+}
+
+// NewNackGenerator creates a NackGenerator for the media source mediaSSRC,
+// sending NACKs from senderSSRC.
+func NewNackGenerator(senderSSRC, mediaSSRC uint32) *NackGenerator {
+	return &NackGenerator{
+		SenderSSRC:  senderSSRC,
+		MediaSSRC:   mediaSSRC,
+		RTT:         defaultNackRTT,
+		MaxRetries:  defaultNackMaxRetries,
+		MaxAge:      defaultNackMaxAge,
+		MaxListSize: defaultNackMaxListSize,
+		missing:     map[uint16]*missingPacket{},
+	}
+}
+
+// ReceivePacket records the RTP packet with sequence number seq received at
+// time now. Sequence numbers are compared in serial number arithmetic, so
+// wraparound and reordering are handled. A jump forward of more than
+// MaxListSize packets is treated as a restart of the stream.
+func (g *NackGenerator) ReceivePacket(seq uint16, now time.Time) {
+	if !g.initialized {
+		g.initialized = true
+		g.highest = seq
+
+		return
+	}
+
+	diff := int16(seq - g.highest) //nolint:gosec // G115, serial number arithmetic
+	switch {
+	case diff <= 0:
+		// Late, retransmitted or duplicate packet.
+		delete(g.missing, seq)
+	case int(diff) > g.MaxListSize:
+		clear(g.missing)
+		g.highest = seq
+	default:
+		for s := g.highest + 1; s != seq; s++ {
+			g.missing[s] = &missingPacket{detected: now}
+		}
+		g.highest = seq
+		g.trim()
+	}
+}
+
+// Missing returns the sequence numbers of the missing packets being tracked,
+// oldest first.
+func (g *NackGenerator) Missing() []uint16 {
+	seqs := make([]uint16, 0, len(g.missing))
+	for seq := range g.missing {
+		seqs = append(seqs, seq)
+	}
+	slices.SortFunc(seqs, g.compare)
+
+	return seqs
+}
+
+// Poll returns the TransportLayerNack to send at time now, or nil if no
+// packet is to be requested. Packets that have been requested MaxRetries
+// times or that are older than MaxAge are given up.
+func (g *NackGenerator) Poll(now time.Time) *TransportLayerNack {
+	var seqs []uint16
+	for seq, packet := range g.missing {
+		if packet.retries >= g.MaxRetries || now.Sub(packet.detected) > g.MaxAge {
+			delete(g.missing, seq)
+
+			continue
+		}
+		if g.highest-seq <= g.ReorderTolerance {
+			continue
+		}
+		if packet.retries > 0 && now.Sub(packet.lastSent) < g.RTT {
+			continue
+		}
+		packet.retries++
+		packet.lastSent = now
+		seqs = append(seqs, seq)
+	}
+	if len(seqs) == 0 {
+		return nil
+	}
+	slices.SortFunc(seqs, g.compare)
+
+	return &TransportLayerNack{
+		SenderSSRC: g.SenderSSRC,
+		MediaSSRC:  g.MediaSSRC,
+		Nacks:      NackPairsFromSequenceNumbers(seqs),
+	}
+}
+
+// trim gives up the oldest missing packets beyond MaxListSize.
+func (g *NackGenerator) trim() {
+	if len(g.missing) <= g.MaxListSize {
+		return
+	}
+	for _, seq := range g.Missing()[:len(g.missing)-g.MaxListSize] {
+		delete(g.missing, seq)
+	}
+}
+
+// compare orders sequence numbers by their distance to the highest one
+// received, oldest first.
+func (g *NackGenerator) compare(a, b uint16) int {
+	return cmp.Compare(g.highest-b, g.highest-a)
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNackGeneratorHoles(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	generator := NewNackGenerator(0x1, 0xA)
+
+	for _, seq := range []uint16{100, 101, 104, 105, 103, 110} {
+		generator.ReceivePacket(seq, now)
+	}
+	assert.Equal(t, []uint16{102, 106, 107, 108, 109}, generator.Missing())
+
+	assert.Equal(t, &TransportLayerNack{
+		SenderSSRC: 0x1,
+		MediaSSRC:  0xA,
+		Nacks:      []NackPair{{PacketID: 102, LostPackets: 0b1111 << 3}},
+	}, generator.Poll(now))
+
+	// Retransmissions are removed from the list.
+	generator.ReceivePacket(102, now)
+	generator.ReceivePacket(107, now)
+	assert.Equal(t, []uint16{106, 108, 109}, generator.Missing())
+
+	// Packets are requested again after one RTT.
+	assert.Nil(t, generator.Poll(now.Add(50*time.Millisecond)))
+	assert.Equal(t, &TransportLayerNack{
+		SenderSSRC: 0x1,
+		MediaSSRC:  0xA,
+		Nacks:      []NackPair{{PacketID: 106, LostPackets: 0b110}},
+	}, generator.Poll(now.Add(100*time.Millisecond)))
+}
+
+func TestNackGeneratorReorderTolerance(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	generator := NewNackGenerator(0x1, 0xA)
+	generator.ReorderTolerance = 2
+
+	generator.ReceivePacket(10, now)
+	generator.ReceivePacket(12, now)
+	assert.Nil(t, generator.Poll(now))
+	generator.ReceivePacket(13, now)
+	assert.Nil(t, generator.Poll(now))
+
+	// Reordered packet arriving within the tolerance is never requested.
+	generator.ReceivePacket(11, now)
+	generator.ReceivePacket(15, now)
+	generator.ReceivePacket(16, now)
+	assert.Nil(t, generator.Poll(now))
+	generator.ReceivePacket(17, now)
+	assert.Equal(t, []NackPair{{PacketID: 14}}, generator.Poll(now).Nacks)
+}
+
+func TestNackGeneratorWraparound(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	generator := NewNackGenerator(0x1, 0xA)
+
+	generator.ReceivePacket(65533, now)
+	generator.ReceivePacket(2, now)
+	assert.Equal(t, []uint16{65534, 65535, 0, 1}, generator.Missing())
+	assert.Equal(t, []NackPair{{PacketID: 65534, LostPackets: 0b111}}, generator.Poll(now).Nacks)
+
+	// Old packets from before the wrap are not holes.
+	generator.ReceivePacket(65535, now)
+	generator.ReceivePacket(65000, now)
+	assert.Equal(t, []uint16{65534, 0, 1}, generator.Missing())
+}
+
+func TestNackGeneratorGiveUp(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	generator := NewNackGenerator(0x1, 0xA)
+	generator.MaxRetries = 2
+	generator.MaxAge = time.Hour
+
+	generator.ReceivePacket(1, now)
+	generator.ReceivePacket(3, now)
+	assert.NotNil(t, generator.Poll(now))
+	assert.NotNil(t, generator.Poll(now.Add(time.Second)))
+	assert.Nil(t, generator.Poll(now.Add(2*time.Second)))
+	assert.Empty(t, generator.Missing())
+
+	// Packets older than MaxAge are given up.
+	generator.MaxAge = time.Second
+	generator.ReceivePacket(5, now)
+	assert.Equal(t, []uint16{4}, generator.Missing())
+	assert.Nil(t, generator.Poll(now.Add(2*time.Second)))
+	assert.Empty(t, generator.Missing())
+}
+
+func TestNackGeneratorListSize(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	generator := NewNackGenerator(0x1, 0xA)
+	generator.MaxListSize = 4
+
+	generator.ReceivePacket(0, now)
+	generator.ReceivePacket(3, now)
+	generator.ReceivePacket(6, now)
+	assert.Equal(t, []uint16{1, 2, 4, 5}, generator.Missing())
+
+	// The oldest missing packet is given up.
+	generator.ReceivePacket(8, now)
+	assert.Equal(t, []uint16{2, 4, 5, 7}, generator.Missing())
+
+	// A large jump restarts tracking.
+	generator.ReceivePacket(1000, now)
+	assert.Empty(t, generator.Missing())
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import "time"
+
+// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and
+// the Unix epoch (1970).
+const ntpEpochOffset = 2208988800
+
+// toNTPTime converts a time.Time to a 64-bit NTP timestamp as carried by
+// SenderReport.NTPTime.
+func toNTPTime(t time.Time) uint64 {
+	secs := uint64(t.Unix() + ntpEpochOffset)                     //nolint:gosec // G115
+	frac := (uint64(t.Nanosecond())<<32 + 500000000) / 1000000000 //nolint:gosec // G115
+
+	return secs<<32 + frac
+}
+
+// ntpToTime converts a 64-bit NTP timestamp to a time.Time.
+func ntpToTime(ntp uint64) time.Time {
+	secs := int64(ntp>>32) - ntpEpochOffset
+	nsec := ((ntp&0xFFFFFFFF)*1000000000 + 1<<31) >> 32
+
+	return time.Unix(secs, int64(nsec)) //nolint:gosec // G115
+}
+
+// toCompactNTP returns the middle 32 bits of a 64-bit NTP timestamp, the
+// format used by ReceptionReport.LastSenderReport.
+func toCompactNTP(ntp uint64) uint32 {
+	return uint32(ntp >> 16) //nolint:gosec // G115
+}
+
+// compactNTPToDuration converts a duration expressed in units of 1/65536
+// seconds (e.g. ReceptionReport.Delay) to a time.Duration.
+func compactNTPToDuration(v uint32) time.Duration {
+	return time.Duration((int64(v)*int64(time.Second) + 1<<15) >> 16)
+}
+
+// durationToCompactNTP converts a time.Duration to units of 1/65536 seconds,
+// clamping negative values to zero.
+func durationToCompactNTP(d time.Duration) uint32 {
+	if d <= 0 {
+		return 0
+	}
+
+	return uint32((int64(d)<<16 + int64(time.Second)/2) / int64(time.Second)) //nolint:gosec // G115
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNTPTimeRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		Name string
+		Time time.Time
+		NTP  uint64
+	}{
+		{
+			Name: "unix epoch",
+			Time: time.Unix(0, 0),
+			NTP:  ntpEpochOffset << 32,
+		},
+		{
+			Name: "half second",
+			Time: time.Unix(1, 500000000),
+			NTP:  (ntpEpochOffset+1)<<32 | 0x80000000,
+		},
+		{
+			Name: "nanosecond precision",
+			Time: time.Unix(1700000000, 123456789),
+			NTP:  toNTPTime(time.Unix(1700000000, 123456789)),
+		},
+	} {
+		assert.Equal(t, test.NTP, toNTPTime(test.Time), "Time to NTP: %s", test.Name)
+		assert.True(t, test.Time.Equal(ntpToTime(test.NTP)), "NTP to Time: %s", test.Name)
+	}
+}
+
+func TestCompactNTP(t *testing.T) {
+	assert.Equal(t, uint32(0x5678abcd), toCompactNTP(0x12345678abcdef01))
+
+	assert.Equal(t, uint32(65536), durationToCompactNTP(time.Second))
+	assert.Equal(t, uint32(0), durationToCompactNTP(-time.Second))
+	assert.Equal(t, 1500*time.Millisecond, compactNTPToDuration(98304))
+	assert.Equal(t, 2*time.Second, compactNTPToDuration(durationToCompactNTP(2*time.Second)))
+}
@@ -0,0 +1,351 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"slices"
+)
+
+// Order of the packets within a compound packet built by a Packer, after
+// the header report: ReceiverReports, then the SourceDescription followed
+// by further SenderReports and SourceDescriptions, then the other packets,
+// and Goodbyes last.
+const (
+	packOrderReceiverReport = iota
+	packOrderSourceDescription
+	packOrderOther
+	packOrderGoodbye
+)
+
+// Sizes of the list entries of the packets a Packer splits.
+const (
+	nackPairLength = 4
+	fciEntryLength = 8
+)
+
+// Packer packs RTCP packets into one or more compound packets that each fit
+// in an MTU, following the rules of RFC 3550 Section 6.1.
+//
+// The first SenderReport or ReceiverReport and the first SourceDescription
+// carrying a CNAME form the mandatory header of the compound packet. Within
+// each compound packet, the header report comes first, followed by the
+// ReceiverReports carrying additional report blocks, the SourceDescription,
+// the other packets such as feedback messages and ExtendedReports, and the
+// Goodbyes last. When a second compound packet is needed, it repeats the
+// header report without its report blocks and the CNAME.
+//
+// Reports with more than 31 blocks are split into additional
+// ReceiverReports, and TransportLayerNack, TMMBR, TMMBN and FullIntraRequest
+// packets with too many entries for one compound packet are split into
+// several packets.
+type Packer struct {
+	// MTU is the maximum size of a compound packet in octets.
+	MTU int
+}
+
+// NewPacker creates a Packer for the given MTU.
+func NewPacker(mtu int) *Packer {
+	return &Packer{MTU: mtu}
+}
+
+// Pack packs pkts, which may include CompoundPackets, into compound packets.
+// Packets that cannot fit in the MTU even on their own are left out and
+// returned as unfit.
+func (p *Packer) Pack(pkts []Packet) (compounds []CompoundPacket, unfit []Packet, err error) {
+	pkts = flattenPackets(pkts)
+
+	reportIndex := slices.IndexFunc(pkts, isReport)
+	if reportIndex < 0 {
+		return nil, nil, errBadFirstPacket
+	}
+	sdesIndex := slices.IndexFunc(pkts, hasCNAME)
+	if sdesIndex < 0 {
+		return nil, nil, errMissingCNAME
+	}
+
+	report := pkts[reportIndex]
+	sdes, _ := pkts[sdesIndex].(*SourceDescription)
+	repeated := packedCompound{reports: []Packet{withoutReports(report)}, sdes: cnameOnly(sdes)}
+	space := p.MTU - repeated.size()
+
+	first, rest := splitReport(report)
+	if parts := splitPacket(first, p.MTU-sdes.MarshalSize()); len(parts) > 1 {
+		first, rest = parts[0], append(parts[1:], rest...)
+	}
+	for i, pkt := range pkts {
+		if i == reportIndex || i == sdesIndex {
+			continue
+		}
+		head, more := splitReport(pkt)
+		rest = append(rest, head)
+		rest = append(rest, more...)
+	}
+	var split []Packet
+	for _, pkt := range rest {
+		split = append(split, splitPacket(pkt, space)...)
+	}
+	slices.SortStableFunc(split, func(a, b Packet) int {
+		return packOrder(a) - packOrder(b)
+	})
+
+	current := packedCompound{reports: []Packet{first}, sdes: sdes}
+	if current.size() > p.MTU {
+		return nil, nil, errPacketTooLarge
+	}
+	for _, pkt := range split {
+		if current.size()+pkt.MarshalSize() <= p.MTU {
+			current.add(pkt)
+
+			continue
+		}
+		if space < pkt.MarshalSize() {
+			unfit = append(unfit, pkt)
+
+			continue
+		}
+		compounds = append(compounds, current.compound())
+		current = packedCompound{reports: []Packet{repeated.reports[0]}, sdes: repeated.sdes}
+		current.add(pkt)
+	}
+	compounds = append(compounds, current.compound())
+
+	return compounds, unfit, nil
+}
+
+// Marshal packs pkts like Pack and encodes the compound packets, returning
+// one datagram per compound packet.
+func (p *Packer) Marshal(pkts []Packet) (datagrams [][]byte, unfit []Packet, err error) {
+	compounds, unfit, err := p.Pack(pkts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, compound := range compounds {
+		raw, err := compound.Marshal()
+		if err != nil {
+			return nil, nil, err
+		}
+		datagrams = append(datagrams, raw)
+	}
+
+	return datagrams, unfit, nil
+}
+
+type packedCompound struct {
+	reports []Packet
+	sdes    *SourceDescription
+	others  []Packet
+}
+
+func (c *packedCompound) add(pkt Packet) {
+	if _, ok := pkt.(*ReceiverReport); ok && len(c.others) == 0 {
+		c.reports = append(c.reports, pkt)
+
+		return
+	}
+	c.others = append(c.others, pkt)
+}
+
+func (c *packedCompound) size() int {
+	size := c.sdes.MarshalSize()
+	for _, pkt := range c.reports {
+		size += pkt.MarshalSize()
+	}
+	for _, pkt := range c.others {
+		size += pkt.MarshalSize()
+	}
+
+	return size
+}
+
+func (c *packedCompound) compound() CompoundPacket {
+	compound := append(CompoundPacket{}, c.reports...)
+	compound = append(compound, c.sdes)
+
+	return append(compound, c.others...)
+}
+
+func packOrder(pkt Packet) int {
+	switch pkt.(type) {
+	case *ReceiverReport:
+		return packOrderReceiverReport
+	case *SenderReport, *SourceDescription:
+		return packOrderSourceDescription
+	case *Goodbye:
+		return packOrderGoodbye
+	default:
+		return packOrderOther
+	}
+}
+
+func isReport(pkt Packet) bool {
+	switch pkt.(type) {
+	case *SenderReport, *ReceiverReport:
+		return true
+	}
+
+	return false
+}
+
+func hasCNAME(pkt Packet) bool {
+	sdes, ok := pkt.(*SourceDescription)
+	if !ok {
+		return false
+	}
+	for _, chunk := range sdes.Chunks {
+		for _, item := range chunk.Items {
+			if item.Type == SDESCNAME {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func reportSSRC(pkt Packet) uint32 {
+	switch report := pkt.(type) {
+	case *SenderReport:
+		return report.SSRC
+	case *ReceiverReport:
+		return report.SSRC
+	}
+
+	return 0
+}
+
+// splitReport returns the report with at most 31 report blocks, and
+// ReceiverReports carrying the remaining blocks.
+func splitReport(pkt Packet) (Packet, []Packet) {
+	var reports []ReceptionReport
+	switch report := pkt.(type) {
+	case *SenderReport:
+		if len(report.Reports) <= countMax {
+			return pkt, nil
+		}
+		head := *report
+		head.Reports = report.Reports[:countMax]
+		pkt, reports = &head, report.Reports[countMax:]
+	case *ReceiverReport:
+		if len(report.Reports) <= countMax {
+			return pkt, nil
+		}
+		head := *report
+		head.Reports = report.Reports[:countMax]
+		pkt, reports = &head, report.Reports[countMax:]
+	default:
+		return pkt, nil
+	}
+
+	var extra []Packet
+	for len(reports) > 0 {
+		n := min(len(reports), countMax)
+		extra = append(extra, &ReceiverReport{SSRC: reportSSRC(pkt), Reports: reports[:n]})
+		reports = reports[n:]
+	}
+
+	return pkt, extra
+}
+
+// withoutReports returns the report without its report blocks and profile
+// extensions, to be repeated in each compound packet.
+func withoutReports(pkt Packet) Packet {
+	switch report := pkt.(type) {
+	case *SenderReport:
+		head := *report
+		head.Reports = nil
+		head.ProfileExtensions = nil
+
+		return &head
+	case *ReceiverReport:
+		return &ReceiverReport{SSRC: report.SSRC}
+	}
+
+	return pkt
+}
+
+// cnameOnly returns the chunks of the SourceDescription carrying a CNAME,
+// with only that item.
+func cnameOnly(sdes *SourceDescription) *SourceDescription {
+	out := &SourceDescription{}
+	for _, chunk := range sdes.Chunks {
+		for _, item := range chunk.Items {
+			if item.Type == SDESCNAME {
+				out.Chunks = append(out.Chunks, SourceDescriptionChunk{Source: chunk.Source, Items: []SourceDescriptionItem{item}})
+
+				break
+			}
+		}
+	}
+
+	return out
+}
+
+// splitPacket splits packets with lists of entries into packets of at most
+// space octets. Other packets are returned as is.
+func splitPacket(pkt Packet, space int) []Packet {
+	if pkt.MarshalSize() <= space {
+		return []Packet{pkt}
+	}
+
+	var out []Packet
+	switch p := pkt.(type) {
+	case *SenderReport:
+		base := headerLength + srHeaderLength
+		for i, reports := range splitEntries(p.Reports, base, receptionReportLength, space) {
+			if i == 0 {
+				head := *p
+				head.Reports = reports
+				out = append(out, &head)
+
+				continue
+			}
+			out = append(out, &ReceiverReport{SSRC: p.SSRC, Reports: reports})
+		}
+	case *ReceiverReport:
+		for _, reports := range splitEntries(p.Reports, headerLength+ssrcLength, receptionReportLength, space) {
+			out = append(out, &ReceiverReport{SSRC: p.SSRC, Reports: reports})
+		}
+	case *TransportLayerNack:
+		for _, nacks := range splitEntries(p.Nacks, headerLength+nackOffset, nackPairLength, space) {
+			out = append(out, &TransportLayerNack{SenderSSRC: p.SenderSSRC, MediaSSRC: p.MediaSSRC, Nacks: nacks})
+		}
+	case *TMMBR:
+		for _, entries := range splitEntries(p.Entries, headerLength+firOffset, fciEntryLength, space) {
+			out = append(out, &TMMBR{SenderSSRC: p.SenderSSRC, Entries: entries})
+		}
+	case *TMMBN:
+		for _, entries := range splitEntries(p.Entries, headerLength+firOffset, fciEntryLength, space) {
+			out = append(out, &TMMBN{SenderSSRC: p.SenderSSRC, Entries: entries})
+		}
+	case *FullIntraRequest:
+		for _, entries := range splitEntries(p.FIR, headerLength+firOffset, fciEntryLength, space) {
+			out = append(out, &FullIntraRequest{SenderSSRC: p.SenderSSRC, MediaSSRC: p.MediaSSRC, FIR: entries})
+		}
+	}
+	if len(out) == 0 {
+		return []Packet{pkt}
+	}
+
+	return out
+}
+
+// splitEntries splits entries of entrySize octets into lists that fit in
+// space octets together with base octets of header. Entries never fit if
+// not even one does, which is left for the caller to report.
+func splitEntries[T any](entries []T, base, entrySize, space int) [][]T {
+	n := (space - base) / entrySize
+	if n < 1 {
+		return nil
+	}
+
+	var out [][]T
+	for len(entries) > 0 {
+		k := min(len(entries), n)
+		out = append(out, entries[:k])
+		entries = entries[k:]
+	}
+
+	return out
+}
@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackerOrder(t *testing.T) {
+	pli := &PictureLossIndication{SenderSSRC: 0x1, MediaSSRC: 0xA}
+	bye := &Goodbye{Sources: []uint32{0x1}}
+	xr := &ExtendedReport{SenderSSRC: 0x1, Reports: []ReportBlock{&ReceiverReferenceTimeReportBlock{NTPTimestamp: 1}}}
+	sdes := NewCNAMESourceDescription(0x1, "me@example.com")
+	rr := &ReceiverReport{SSRC: 0x1, Reports: []ReceptionReport{{SSRC: 0xA}}}
+
+	compounds, unfit, err := NewPacker(1200).Pack([]Packet{bye, pli, sdes, xr, rr})
+	assert.NoError(t, err)
+	assert.Empty(t, unfit)
+	assert.Equal(t, []CompoundPacket{{rr, sdes, pli, xr, bye}}, compounds)
+	assert.NoError(t, compounds[0].Validate())
+}
+
+func TestPackerErrors(t *testing.T) {
+	packer := NewPacker(1200)
+
+	_, _, err := packer.Pack([]Packet{NewCNAMESourceDescription(0x1, "me@example.com")})
+	assert.ErrorIs(t, err, errBadFirstPacket)
+
+	_, _, err = packer.Pack([]Packet{&ReceiverReport{SSRC: 0x1}, &PictureLossIndication{}})
+	assert.ErrorIs(t, err, errMissingCNAME)
+
+	_, _, err = NewPacker(20).Pack([]Packet{
+		&ReceiverReport{SSRC: 0x1},
+		NewCNAMESourceDescription(0x1, "me@example.com"),
+	})
+	assert.ErrorIs(t, err, errPacketTooLarge)
+}
+
+func TestPackerSplitReports(t *testing.T) {
+	reports := make([]ReceptionReport, 70)
+	for i := range reports {
+		reports[i].SSRC = uint32(i + 0x100) //nolint:gosec // G115
+	}
+	sr := &SenderReport{SSRC: 0x1, NTPTime: 0x1234, Reports: reports}
+	sdes := NewCNAMESourceDescription(0x1, "me@example.com")
+
+	compounds, unfit, err := NewPacker(2000).Pack([]Packet{sr, sdes})
+	assert.NoError(t, err)
+	assert.Empty(t, unfit)
+	assert.Equal(t, []CompoundPacket{{
+		&SenderReport{SSRC: 0x1, NTPTime: 0x1234, Reports: reports[:31]},
+		&ReceiverReport{SSRC: 0x1, Reports: reports[31:62]},
+		&ReceiverReport{SSRC: 0x1, Reports: reports[62:]},
+		sdes,
+	}}, compounds)
+
+	// The second compound packet repeats the SenderReport without blocks.
+	compounds, unfit, err = NewPacker(1500).Pack([]Packet{sr, sdes})
+	assert.NoError(t, err)
+	assert.Empty(t, unfit)
+	assert.Equal(t, []CompoundPacket{
+		{&SenderReport{SSRC: 0x1, NTPTime: 0x1234, Reports: reports[:31]}, sdes},
+		{
+			&SenderReport{SSRC: 0x1, NTPTime: 0x1234},
+			&ReceiverReport{SSRC: 0x1, Reports: reports[31:62]},
+			&ReceiverReport{SSRC: 0x1, Reports: reports[62:]},
+			sdes,
+		},
+	}, compounds)
+
+	// With a small MTU, the header report carries fewer blocks.
+	compounds, unfit, err = NewPacker(200).Pack([]Packet{sr, sdes})
+	assert.NoError(t, err)
+	assert.Empty(t, unfit)
+	var reported []uint32
+	for _, compound := range compounds {
+		raw, err := compound.Marshal()
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(raw), 200)
+		reported = append(reported, reportedSSRCs(compound)...)
+	}
+	assert.Len(t, reported, 70)
+	assert.Equal(t, uint32(0x100), reported[0])
+	assert.Equal(t, uint32(0x100+69), reported[69])
+}
+
+func TestPackerSplitFeedback(t *testing.T) {
+	rr := &ReceiverReport{SSRC: 0x1}
+	sdes := &SourceDescription{Chunks: []SourceDescriptionChunk{{
+		Source: 0x1,
+		Items: []SourceDescriptionItem{
+			{Type: SDESCNAME, Text: "me@example.com"},
+			{Type: SDESTool, Text: "pion"},
+		},
+	}}}
+	nacks := make([]NackPair, 50)
+	for i := range nacks {
+		nacks[i] = NackPair{PacketID: uint16(i * 100)} //nolint:gosec // G115
+	}
+	fir := make([]FIREntry, 20)
+	for i := range fir {
+		fir[i] = FIREntry{SSRC: uint32(i), SequenceNumber: 1} //nolint:gosec // G115
+	}
+	tmmbr := make([]TMMBREntry, 20)
+	for i := range tmmbr {
+		tmmbr[i] = TMMBREntry{MediaSSRC: uint32(i), Bitrate: 100000} //nolint:gosec // G115
+	}
+	app := &ApplicationDefined{SSRC: 0x1, Name: "TEST", Data: make([]byte, 200)}
+
+	const mtu = 128
+	datagrams, unfit, err := NewPacker(mtu).Marshal([]Packet{
+		rr,
+		sdes,
+		&TransportLayerNack{SenderSSRC: 0x1, MediaSSRC: 0xA, Nacks: nacks},
+		&FullIntraRequest{SenderSSRC: 0x1, MediaSSRC: 0xA, FIR: fir},
+		&TMMBR{SenderSSRC: 0x1, Entries: tmmbr},
+		app,
+		&Goodbye{Sources: []uint32{0x1}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{app}, unfit)
+
+	var gotNacks []NackPair
+	var gotFIR []FIREntry
+	var gotTMMBR []TMMBREntry
+	for i, raw := range datagrams {
+		assert.LessOrEqual(t, len(raw), mtu)
+
+		var compound CompoundPacket
+		assert.NoError(t, compound.Unmarshal(raw))
+		assert.NoError(t, compound.Validate())
+		assert.Equal(t, rr.SSRC, compound[0].(*ReceiverReport).SSRC) //nolint:forcetypeassert
+		cname, err := compound.CNAME()
+		assert.NoError(t, err)
+		assert.Equal(t, "me@example.com", cname)
+		if i > 0 {
+			// Only the CNAME is repeated.
+			assert.Equal(t, NewCNAMESourceDescription(0x1, "me@example.com"), compound[1])
+		}
+
+		for j, pkt := range compound[2:] {
+			switch p := pkt.(type) {
+			case *TransportLayerNack:
+				gotNacks = append(gotNacks, p.Nacks...)
+			case *FullIntraRequest:
+				gotFIR = append(gotFIR, p.FIR...)
+			case *TMMBR:
+				gotTMMBR = append(gotTMMBR, p.Entries...)
+			case *Goodbye:
+				assert.Equal(t, len(datagrams)-1, i, "goodbye is in the last datagram")
+				assert.Equal(t, len(compound)-3, j, "goodbye is the last packet")
+			}
+		}
+	}
+	assert.Equal(t, nacks, gotNacks)
+	assert.Equal(t, fir, gotFIR)
+	assert.Equal(t, tmmbr, gotTMMBR)
+}
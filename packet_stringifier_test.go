@@ -488,8 +488,8 @@ func TestPrint(t *testing.T) {
 			&TMMBR{
 				SenderSSRC: 0x902f9e2e,
 				Entries: []TMMBREntry{
-					{0x902f9e2e, 9812743},
-					{0xdeadbeef, 8435793},
+					{0x902f9e2e, 9812743, 40},
+					{0xdeadbeef, 8435793, 0},
 				},
 			},
 			"rtcp.TMMBR:\n" +
@@ -497,10 +497,12 @@ func TestPrint(t *testing.T) {
 				"\tEntries:\n" +
 				"\t\t0:\n" +
 				"\t\t\tMediaSSRC: 2419039790\n" +
-				"\t\t\tBitrate: 9.812743e+06\n" +
+				"\t\t\tBitrate: 9812743\n" +
+				"\t\t\tOverhead: 40\n" +
 				"\t\t1:\n" +
 				"\t\t\tMediaSSRC: 3735928559\n" +
-				"\t\t\tBitrate: 8.435793e+06\n",
+				"\t\t\tBitrate: 8435793\n" +
+				"\t\t\tOverhead: 0\n",
 		},
 		{
 			&TransportLayerNack{
@@ -191,7 +191,7 @@ func (r ReceiverReport) String() string {
 	out := fmt.Sprintf("ReceiverReport from %x\n", r.SSRC)
 	out += "\tSSRC    \tLost\tLastSequence\n"
 	for _, i := range r.Reports {
-		out += fmt.Sprintf("\t%x\t%d/%d\t%d\n", i.SSRC, i.FractionLost, i.TotalLost, i.LastSequenceNumber)
+		out += fmt.Sprintf("\t%x\t%d/%d\t%d\n", i.SSRC, i.FractionLost, i.CumulativeLost(), i.LastSequenceNumber)
 	}
 	out += fmt.Sprintf("\tProfile Extension Data: %v\n", r.ProfileExtensions)
 
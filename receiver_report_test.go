@@ -92,6 +92,40 @@ func TestReceiverReportUnmarshal(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "negative total lost",
+			Data: []byte{
+				// v=2, p=0, count=1, RR, len=7
+				0x81, 0xc9, 0x0, 0x7,
+				// ssrc=0x902f9e2e
+				0x90, 0x2f, 0x9e, 0x2e,
+				// ssrc=0xbc5e9a40
+				0xbc, 0x5e, 0x9a, 0x40,
+				// fracLost=0, totalLost=-3
+				0x0, 0xff, 0xff, 0xfd,
+				// lastSeq=0x46e1
+				0x0, 0x0, 0x46, 0xe1,
+				// jitter=273
+				0x0, 0x0, 0x1, 0x11,
+				// lsr=0x9f36432
+				0x9, 0xf3, 0x64, 0x32,
+				// delay=150137
+				0x0, 0x2, 0x4a, 0x79,
+			},
+			Want: ReceiverReport{
+				SSRC: 0x902f9e2e,
+				Reports: []ReceptionReport{{
+					SSRC:               0xbc5e9a40,
+					FractionLost:       0,
+					TotalLost:          0xfffffd,
+					LastSequenceNumber: 0x46e1,
+					Jitter:             273,
+					LastSenderReport:   0x9f36432,
+					Delay:              150137,
+				}},
+				ProfileExtensions: []byte{},
+			},
+		},
 		{
 			Name: "short report",
 			Data: []byte{
@@ -259,3 +293,50 @@ func TestReceiverReportRoundTrip(t *testing.T) {
 		assert.Equalf(t, test.Report, decoded, "%s rr round trip mismatch", test.Name)
 	}
 }
+
+func TestReceptionReportCumulativeLost(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Lost      int64
+		TotalLost uint32
+		Want      int32
+	}{
+		{Name: "zero", Lost: 0, TotalLost: 0, Want: 0},
+		{Name: "positive", Lost: 12345, TotalLost: 12345, Want: 12345},
+		{Name: "negative", Lost: -1, TotalLost: 0xffffff, Want: -1},
+		{Name: "max", Lost: 1<<23 - 1, TotalLost: 0x7fffff, Want: 1<<23 - 1},
+		{Name: "min", Lost: -(1 << 23), TotalLost: 0x800000, Want: -(1 << 23)},
+		{Name: "saturate high", Lost: 1 << 40, TotalLost: 0x7fffff, Want: 1<<23 - 1},
+		{Name: "saturate low", Lost: -(1 << 40), TotalLost: 0x800000, Want: -(1 << 23)},
+	} {
+		var report ReceptionReport
+		report.SetCumulativeLost(test.Lost)
+		assert.Equalf(t, test.TotalLost, report.TotalLost, "SetCumulativeLost %q", test.Name)
+		assert.Equalf(t, test.Want, report.CumulativeLost(), "CumulativeLost %q", test.Name)
+
+		data, err := report.Marshal()
+		assert.NoErrorf(t, err, "Marshal %q", test.Name)
+
+		var decoded ReceptionReport
+		assert.NoErrorf(t, decoded.Unmarshal(data), "Unmarshal %q", test.Name)
+		assert.Equalf(t, test.Want, decoded.CumulativeLost(), "round trip %q", test.Name)
+	}
+
+	// A negative count survives a round trip as the raw 24-bit field.
+	report := ReceptionReport{SSRC: 0x1}
+	report.SetCumulativeLost(-5)
+	data, err := report.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xff, 0xff, 0xfb}, data[totalLostOffset:totalLostOffset+3])
+	var decoded ReceptionReport
+	assert.NoError(t, decoded.Unmarshal(data))
+	assert.Equal(t, report, decoded)
+	assert.Equal(t, int32(-5), decoded.CumulativeLost())
+
+	// Values which do not fit in 24 bits are rejected, sign-extended ones
+	// included, as they would not decode to the same value.
+	for _, totalLost := range []uint32{0x1000000, 0xfffffffb} {
+		_, err = ReceptionReport{TotalLost: totalLost}.Marshal()
+		assert.ErrorIs(t, err, errInvalidTotalLost)
+	}
+}
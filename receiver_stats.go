@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import "time"
+
+// Sequence number validation parameters from RFC 3550 Appendix A.1.
+const (
+	rtpSeqMod     = 1 << 16
+	maxDropout    = 3000
+	maxMisorder   = 100
+	minSequential = 2
+)
+
+// ReceiverStats keeps the reception statistics of a single synchronization
+// source and produces the ReceptionReport blocks describing it.
+//
+// Sequence number validation, probation and restart detection follow
+// RFC 3550 Appendix A.1, loss accounting Appendix A.3, and interarrival
+// jitter Appendix A.8.
+type ReceiverStats struct {
+	// SSRC of the media source the statistics pertain to.
+	SSRC uint32
+
+	initialized bool
+	probation   int
+	maxSeq      uint16
+	cycles      uint32
+	baseSeq     uint32
+	badSeq      uint32
+
+	received      uint32
+	expectedPrior uint32
+	receivedPrior uint32
+
+	arrivalBase   time.Time
+	haveTransit   bool
+	lastArrival   int64
+	lastRTPTime   uint32
+	jitter        float64
+	lastSR        uint32
+	lastSRArrival time.Time
+}
+
+// NewReceiverStats creates ReceiverStats for the given media source.
+func NewReceiverStats(ssrc uint32) *ReceiverStats {
+	return &ReceiverStats{SSRC: ssrc}
+}
+
+// ReceivePacket records the arrival of an RTP packet with the given sequence
+// number and RTP timestamp. clockRate is the RTP clock rate of the payload in
+// Hz and is used for the jitter calculation; a zero clock rate leaves the
+// jitter estimate untouched.
+//
+// It returns false while the source is still on probation and for packets
+// which are considered invalid, such as a large jump in sequence numbers
+// that has not yet been confirmed by a following packet.
+func (s *ReceiverStats) ReceivePacket(seq uint16, rtpTime uint32, arrival time.Time, clockRate uint32) bool {
+	if !s.initialized {
+		s.initSeq(seq)
+		s.maxSeq = seq - 1
+		s.probation = minSequential
+		s.initialized = true
+		s.arrivalBase = arrival
+	}
+
+	if !s.updateSeq(seq) {
+		return false
+	}
+
+	s.updateJitter(rtpTime, arrival, clockRate)
+
+	return true
+}
+
+// ReceiveSenderReport records the arrival of a SenderReport from the media
+// source, which is reflected in the LSR and DLSR fields of the next reports.
+func (s *ReceiverStats) ReceiveSenderReport(sr *SenderReport, arrival time.Time) {
+	s.lastSR = toCompactNTP(sr.NTPTime)
+	s.lastSRArrival = arrival
+}
+
+// PacketsReceived returns the number of valid packets received.
+func (s *ReceiverStats) PacketsReceived() uint32 {
+	return s.received
+}
+
+// ExtendedHighestSequenceNumber returns the highest sequence number received,
+// extended with the count of sequence number cycles.
+func (s *ReceiverStats) ExtendedHighestSequenceNumber() uint32 {
+	return s.cycles + uint32(s.maxSeq)
+}
+
+// PacketsLost returns the cumulative number of packets lost. The value is
+// negative if duplicates have been received.
+func (s *ReceiverStats) PacketsLost() int64 {
+	if s.received == 0 {
+		return 0
+	}
+
+	return int64(s.expected()) - int64(s.received)
+}
+
+// Report returns a ReceptionReport describing the source at time now and
+// starts a new reporting interval for the fraction lost calculation.
+func (s *ReceiverStats) Report(now time.Time) ReceptionReport {
+	report := ReceptionReport{
+		SSRC:               s.SSRC,
+		LastSequenceNumber: s.ExtendedHighestSequenceNumber(),
+		Jitter:             uint32(s.jitter),
+		LastSenderReport:   s.lastSR,
+	}
+
+	if s.received > 0 {
+		expected := s.expected()
+		expectedInterval := expected - s.expectedPrior
+		receivedInterval := s.received - s.receivedPrior
+		s.expectedPrior = expected
+		s.receivedPrior = s.received
+
+		lostInterval := int64(expectedInterval) - int64(receivedInterval)
+		if expectedInterval != 0 && lostInterval > 0 {
+			report.FractionLost = uint8((lostInterval << 8) / int64(expectedInterval)) //nolint:gosec // G115
+		}
+
+		report.SetCumulativeLost(s.PacketsLost())
+	}
+
+	if !s.lastSRArrival.IsZero() {
+		report.Delay = durationToCompactNTP(now.Sub(s.lastSRArrival))
+	}
+
+	return report
+}
+
+func (s *ReceiverStats) expected() uint32 {
+	return s.ExtendedHighestSequenceNumber() - s.baseSeq + 1
+}
+
+func (s *ReceiverStats) initSeq(seq uint16) {
+	s.baseSeq = uint32(seq)
+	s.maxSeq = seq
+	s.badSeq = rtpSeqMod + 1 // so seq == badSeq is false
+	s.cycles = 0
+	s.received = 0
+	s.receivedPrior = 0
+	s.expectedPrior = 0
+}
+
+func (s *ReceiverStats) updateSeq(seq uint16) bool {
+	udelta := seq - s.maxSeq
+
+	// Source is not valid until minSequential packets with
+	// sequential sequence numbers have been received.
+	switch {
+	case s.probation > 0:
+		if seq != s.maxSeq+1 {
+			s.probation = minSequential - 1
+			s.maxSeq = seq
+
+			return false
+		}
+
+		s.probation--
+		s.maxSeq = seq
+		if s.probation > 0 {
+			return false
+		}
+		s.initSeq(seq)
+	case udelta < maxDropout:
+		// in order, with permissible gap
+		if seq < s.maxSeq {
+			// Sequence number wrapped - count another 64K cycle.
+			s.cycles += rtpSeqMod
+		}
+		s.maxSeq = seq
+	case udelta <= rtpSeqMod-maxMisorder:
+		// the sequence number made a very large jump
+		if uint32(seq) != s.badSeq {
+			s.badSeq = (uint32(seq) + 1) & (rtpSeqMod - 1)
+
+			return false
+		}
+		// Two sequential packets -- assume that the other side
+		// restarted without telling us so just re-sync
+		// (i.e., pretend this was the first packet).
+		s.initSeq(seq)
+		s.haveTransit = false
+	default:
+		// duplicate or reordered packet
+	}
+
+	s.received++
+
+	return true
+}
+
+func (s *ReceiverStats) updateJitter(rtpTime uint32, arrival time.Time, clockRate uint32) {
+	if clockRate == 0 {
+		return
+	}
+
+	// Arrival time converted to RTP timestamp units.
+	arrivalTicks := durationToRTPTicks(arrival.Sub(s.arrivalBase), clockRate)
+
+	if s.haveTransit {
+		// D(i-1,i) = (Rj - Ri) - (Sj - Si), with the RTP timestamp
+		// difference taken modulo 2^32.
+		d := float64((arrivalTicks - s.lastArrival) - int64(int32(rtpTime-s.lastRTPTime))) //nolint:gosec // G115
+		if d < 0 {
+			d = -d
+		}
+		s.jitter += (d - s.jitter) / 16
+	}
+
+	s.haveTransit = true
+	s.lastArrival = arrivalTicks
+	s.lastRTPTime = rtpTime
+}
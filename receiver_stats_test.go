@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiverStatsProbation(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stats := NewReceiverStats(0x1234)
+
+	// The first packet only starts the probation.
+	assert.False(t, stats.ReceivePacket(100, 0, now, 90000))
+	// A non-sequential packet restarts it.
+	assert.False(t, stats.ReceivePacket(200, 0, now, 90000))
+	// A sequential one ends it.
+	assert.True(t, stats.ReceivePacket(201, 0, now, 90000))
+	assert.True(t, stats.ReceivePacket(202, 0, now, 90000))
+
+	assert.Equal(t, uint32(2), stats.PacketsReceived())
+	assert.Equal(t, ReceptionReport{
+		SSRC:               0x1234,
+		LastSequenceNumber: 202,
+	}, stats.Report(now))
+}
+
+func TestReceiverStatsLoss(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stats := NewReceiverStats(0x1234)
+
+	for seq := uint16(0); seq < 10; seq++ {
+		stats.ReceivePacket(seq, 0, now, 0)
+	}
+	report := stats.Report(now)
+	assert.Equal(t, uint8(0), report.FractionLost)
+	assert.Equal(t, uint32(0), report.TotalLost)
+	assert.Equal(t, uint32(9), report.LastSequenceNumber)
+
+	// Lose 5 of the next 20 packets.
+	for seq := uint16(10); seq < 30; seq++ {
+		if seq%4 == 0 {
+			continue
+		}
+		stats.ReceivePacket(seq, 0, now, 0)
+	}
+	report = stats.Report(now)
+	assert.Equal(t, uint8(5*256/20), report.FractionLost)
+	assert.Equal(t, uint32(5), report.TotalLost)
+	assert.Equal(t, int64(5), stats.PacketsLost())
+
+	// Nothing received since the last report.
+	report = stats.Report(now)
+	assert.Equal(t, uint8(0), report.FractionLost)
+	assert.Equal(t, uint32(5), report.TotalLost)
+}
+
+func TestReceiverStatsDuplicates(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stats := NewReceiverStats(0x1234)
+
+	for seq := uint16(0); seq < 10; seq++ {
+		stats.ReceivePacket(seq, 0, now, 0)
+		stats.ReceivePacket(seq, 0, now, 0)
+	}
+
+	// The first packet is consumed by probation, the second one starts the count.
+	assert.Equal(t, int64(-9), stats.PacketsLost())
+	report := stats.Report(now)
+	assert.Equal(t, uint8(0), report.FractionLost)
+	assert.Equal(t, uint32(0xFFFFF7), report.TotalLost)
+	assert.Equal(t, int32(-9), report.CumulativeLost())
+}
+
+func TestReceiverStatsWraparound(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stats := NewReceiverStats(0x1234)
+
+	seq := uint16(65530)
+	for i := 0; i < 20; i++ {
+		stats.ReceivePacket(seq, 0, now, 0)
+		seq++
+	}
+	// A late packet from before the wrap.
+	assert.True(t, stats.ReceivePacket(65533, 0, now, 0))
+
+	report := stats.Report(now)
+	assert.Equal(t, uint32(1<<16+13), report.LastSequenceNumber)
+	assert.Equal(t, uint32(0xFFFFFF), report.TotalLost)
+}
+
+func TestReceiverStatsRestart(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stats := NewReceiverStats(0x1234)
+
+	for seq := uint16(0); seq < 10; seq++ {
+		stats.ReceivePacket(seq, 0, now, 0)
+	}
+
+	// A single large jump is discarded.
+	assert.False(t, stats.ReceivePacket(40000, 0, now, 0))
+	assert.True(t, stats.ReceivePacket(10, 0, now, 0))
+	assert.Equal(t, uint32(10), stats.ExtendedHighestSequenceNumber())
+
+	// Two sequential packets after a jump re-sync the source.
+	assert.False(t, stats.ReceivePacket(50000, 0, now, 0))
+	assert.True(t, stats.ReceivePacket(50001, 0, now, 0))
+	assert.True(t, stats.ReceivePacket(50002, 0, now, 0))
+
+	assert.Equal(t, uint32(2), stats.PacketsReceived())
+	assert.Equal(t, int64(0), stats.PacketsLost())
+	assert.Equal(t, uint32(50002), stats.ExtendedHighestSequenceNumber())
+}
+
+func TestReceiverStatsJitter(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stats := NewReceiverStats(0x1234)
+
+	// 20ms frames arriving on time produce no jitter.
+	for i := 0; i < 10; i++ {
+		arrival := now.Add(time.Duration(i) * 20 * time.Millisecond)
+		stats.ReceivePacket(uint16(i), uint32(i*1800), arrival, 90000) //nolint:gosec // G115
+	}
+	assert.Equal(t, uint32(0), stats.Report(now).Jitter)
+
+	// A single packet arriving 10ms late.
+	stats.ReceivePacket(10, 18000, now.Add(210*time.Millisecond), 90000)
+	// Per RFC 3550 A.8, J = J + (|D| - J)/16 with D = 900 ticks.
+	assert.Equal(t, uint32(900/16), stats.Report(now).Jitter)
+
+	// The next packet is on time again, so |D| = 900 ticks once more and
+	// J = 56.25 + (900 - 56.25)/16.
+	stats.ReceivePacket(11, 19800, now.Add(220*time.Millisecond), 90000)
+	assert.Equal(t, uint32(108), stats.Report(now).Jitter)
+}
+
+func TestReceiverStatsLastSenderReport(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stats := NewReceiverStats(0x1234)
+
+	stats.ReceivePacket(0, 0, now, 90000)
+	stats.ReceivePacket(1, 0, now, 90000)
+	assert.Equal(t, uint32(0), stats.Report(now).LastSenderReport)
+	assert.Equal(t, uint32(0), stats.Report(now).Delay)
+
+	stats.ReceiveSenderReport(&SenderReport{SSRC: 0x1234, NTPTime: 0xda8bd1fcdddda05a}, now)
+
+	report := stats.Report(now.Add(1500 * time.Millisecond))
+	assert.Equal(t, uint32(0xd1fcdddd), report.LastSenderReport)
+	assert.Equal(t, uint32(98304), report.Delay)
+
+	_, err := report.Marshal()
+	assert.NoError(t, err)
+}
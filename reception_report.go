@@ -16,7 +16,11 @@ type ReceptionReport struct {
 	// number with the binary point at the left edge of the field.
 	FractionLost uint8
 	// The total number of RTP data packets from source SSRC that have
-	// been lost since the beginning of reception.
+	// been lost since the beginning of reception, as the raw 24-bit field.
+	// The field is a signed integer which goes negative when duplicates
+	// are received; use CumulativeLost and SetCumulativeLost to access it
+	// with signed semantics. Values above 0xFFFFFF are rejected when
+	// marshaling.
 	TotalLost uint32
 	// The low 16 bits contain the highest sequence number received in an
 	// RTP data packet from source SSRC, and the most significant 16
@@ -47,6 +51,26 @@ const (
 	delayOffset           = 20
 )
 
+// Bounds of the signed 24-bit cumulative number of packets lost.
+const (
+	maxTotalLost = 1<<23 - 1
+	minTotalLost = -(1 << 23)
+)
+
+// CumulativeLost returns the cumulative number of packets lost as the signed
+// 24-bit integer defined by RFC 3550 Section 6.4.1. The value is negative
+// when more packets than expected have been received, e.g. duplicates.
+func (r ReceptionReport) CumulativeLost() int32 {
+	return int32(r.TotalLost<<8) >> 8 //nolint:gosec // G115
+}
+
+// SetCumulativeLost sets the cumulative number of packets lost from a signed
+// packet count, saturating it to the range of a signed 24-bit integer.
+func (r *ReceptionReport) SetCumulativeLost(lost int64) {
+	lost = min(max(lost, minTotalLost), maxTotalLost)
+	r.TotalLost = uint32(lost) & 0xFFFFFF //nolint:gosec // G115
+}
+
 // Marshal encodes the ReceptionReport in binary.
 func (r ReceptionReport) Marshal() ([]byte, error) {
 	/*
@@ -74,7 +98,7 @@ func (r ReceptionReport) Marshal() ([]byte, error) {
 	rawPacket[fractionLostOffset] = r.FractionLost
 
 	// pack TotalLost into 24 bits
-	if r.TotalLost >= (1 << 25) {
+	if r.TotalLost > 0xFFFFFF {
 		return nil, errInvalidTotalLost
 	}
 	tlBytes := rawPacket[totalLostOffset:]
@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"cmp"
+	"io"
+	"slices"
+	"time"
+)
+
+// defaultReporterMTU is the default maximum size of the compound packets
+// built by a Reporter, which leaves room for IP, UDP and SRTCP overhead.
+const defaultReporterMTU = 1200
+
+// Reporter assembles the regular compound RTCP packet of a participant each
+// time it is due, typically when IntervalCalculator.Reconsider returns true,
+// and writes it to a caller-supplied writer.
+//
+// The compound packet starts with a SenderReport if RTP was sent since the
+// last report and a ReceiverReport otherwise. Reception report blocks are
+// included for every remote source a packet was received from since the
+// last report; blocks beyond the 31 a report can carry are sent in
+// additional ReceiverReports. When not all blocks fit in the MTU, they are
+// sent round-robin over consecutive reports. A SourceDescription with our
+// CNAME and the optional NAME and TOOL items follows, and finally an
+// ExtendedReport with the blocks of the registered providers.
+type Reporter struct {
+	// SSRC used for the reports.
+	SSRC uint32
+	// CNAME, NAME and TOOL items of the SourceDescription. NAME and TOOL
+	// are omitted when empty.
+	CNAME string
+	Name  string
+	Tool  string
+	// MTU is the maximum size of a compound packet in octets.
+	MTU int
+	// Sender holds the statistics of the RTP stream we send, if any. Its
+	// SSRC must be the SSRC of the Reporter.
+	Sender *SenderStats
+
+	writer      io.Writer
+	receivers   map[uint32]*reporterReceiver
+	xr          []func(now time.Time) []ReportBlock
+	lastPackets uint32
+	lastSSRC    uint32
+	rotating    bool
+}
+
+type reporterReceiver struct {
+	stats       *ReceiverStats
+	lastPackets uint32
+}
+
+// NewReporter creates a Reporter for the participant with the given SSRC and
+// CNAME, writing the compound packets to w.
+func NewReporter(ssrc uint32, cname string, w io.Writer) *Reporter {
+	return &Reporter{
+		SSRC:      ssrc,
+		CNAME:     cname,
+		MTU:       defaultReporterMTU,
+		writer:    w,
+		receivers: map[uint32]*reporterReceiver{},
+	}
+}
+
+// AddReceiver adds the reception statistics of a remote source to report
+// on. It replaces any statistics added before for the same SSRC.
+func (r *Reporter) AddReceiver(stats *ReceiverStats) {
+	r.receivers[stats.SSRC] = &reporterReceiver{stats: stats}
+}
+
+// RemoveReceiver stops reporting on the remote source ssrc, for example
+// after it left the session.
+func (r *Reporter) RemoveReceiver(ssrc uint32) {
+	delete(r.receivers, ssrc)
+}
+
+// AddExtendedReport registers a provider of ExtendedReport blocks, which is
+// called each time a compound packet is assembled at time now. Providers
+// returning no blocks are skipped.
+func (r *Reporter) AddExtendedReport(provider func(now time.Time) []ReportBlock) {
+	r.xr = append(r.xr, provider)
+}
+
+// Report assembles the compound packet for time now. It starts a new
+// reporting interval for the statistics included.
+func (r *Reporter) Report(now time.Time) (CompoundPacket, error) {
+	sdes := r.sourceDescription()
+	var xr *ExtendedReport
+	for _, provider := range r.xr {
+		if blocks := provider(now); len(blocks) > 0 {
+			if xr == nil {
+				xr = &ExtendedReport{SenderSSRC: r.SSRC}
+			}
+			xr.Reports = append(xr.Reports, blocks...)
+		}
+	}
+
+	sending := r.Sender != nil && r.Sender.HasSent() && r.Sender.PacketCount() != r.lastPackets
+	if r.Sender != nil {
+		r.lastPackets = r.Sender.PacketCount()
+	}
+
+	size := headerLength + ssrcLength + sdes.MarshalSize()
+	if sending {
+		size += srHeaderLength - ssrcLength
+	}
+	if xr != nil {
+		raw, err := xr.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		size += len(raw)
+	}
+	if size > r.MTU {
+		return nil, errPacketTooLarge
+	}
+
+	active := r.activeReceivers(r.MTU - size)
+	blocks := active[:min(len(active), countMax)]
+	var packet CompoundPacket
+	if sending {
+		// The blocks fit in the SenderReport.
+		report, _ := r.Sender.Report(now, blocks...)
+		packet = append(packet, report)
+	} else {
+		packet = append(packet, r.receiverReport(now, blocks))
+	}
+	for active = active[len(blocks):]; len(active) > 0; active = active[len(blocks):] {
+		blocks = active[:min(len(active), countMax)]
+		packet = append(packet, r.receiverReport(now, blocks))
+	}
+
+	packet = append(packet, sdes)
+	if xr != nil {
+		packet = append(packet, xr)
+	}
+
+	return packet, nil
+}
+
+// Send assembles the compound packet for time now and writes it to the
+// writer. It returns the number of octets written, which is what
+// IntervalCalculator.PacketSent expects.
+func (r *Reporter) Send(now time.Time) (int, error) {
+	packet, err := r.Report(now)
+	if err != nil {
+		return 0, err
+	}
+	raw, err := packet.Marshal()
+	if err != nil {
+		return 0, err
+	}
+
+	return r.writer.Write(raw)
+}
+
+func (r *Reporter) sourceDescription() *SourceDescription {
+	items := []SourceDescriptionItem{{Type: SDESCNAME, Text: r.CNAME}}
+	if r.Name != "" {
+		items = append(items, SourceDescriptionItem{Type: SDESName, Text: r.Name})
+	}
+	if r.Tool != "" {
+		items = append(items, SourceDescriptionItem{Type: SDESTool, Text: r.Tool})
+	}
+
+	return &SourceDescription{Chunks: []SourceDescriptionChunk{{Source: r.SSRC, Items: items}}}
+}
+
+func (r *Reporter) receiverReport(now time.Time, receivers []*ReceiverStats) *ReceiverReport {
+	report := &ReceiverReport{SSRC: r.SSRC}
+	for _, receiver := range receivers {
+		report.Reports = append(report.Reports, receiver.Report(now))
+	}
+
+	return report
+}
+
+// activeReceivers returns the sources heard from since the last report that
+// fit in space octets, continuing after the last source reported when not
+// all of them fit.
+func (r *Reporter) activeReceivers(space int) []*ReceiverStats {
+	var active []*ReceiverStats
+	for _, receiver := range r.receivers {
+		if received := receiver.stats.PacketsReceived(); received != receiver.lastPackets {
+			active = append(active, receiver.stats)
+		}
+	}
+	slices.SortFunc(active, func(a, b *ReceiverStats) int {
+		return cmp.Compare(a.SSRC, b.SSRC)
+	})
+
+	// Each block takes 24 octets, and each additional ReceiverReport
+	// carrying up to 31 blocks another 8.
+	fit := 0
+	for used := 0; fit < len(active); fit++ {
+		used += receptionReportLength
+		if fit > 0 && fit%countMax == 0 {
+			used += headerLength + ssrcLength
+		}
+		if used > space {
+			break
+		}
+	}
+
+	if fit == len(active) {
+		r.rotating = false
+	} else {
+		start := 0
+		if r.rotating {
+			start, _ = slices.BinarySearchFunc(active, r.lastSSRC, func(s *ReceiverStats, ssrc uint32) int {
+				return cmp.Compare(s.SSRC, ssrc)
+			})
+			if start < len(active) && active[start].SSRC == r.lastSSRC {
+				start++
+			}
+		}
+		rotated := make([]*ReceiverStats, 0, len(active))
+		rotated = append(rotated, active[start:]...)
+		active = append(rotated, active[:start]...)[:fit]
+		r.rotating = fit > 0
+		if fit > 0 {
+			r.lastSSRC = active[fit-1].SSRC
+		}
+	}
+	for _, stats := range active {
+		r.receivers[stats.SSRC].lastPackets = stats.PacketsReceived()
+	}
+
+	return active
+}
@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// receiveSequence feeds count in-order packets to stats, starting at seq.
+func receiveSequence(stats *ReceiverStats, seq uint16, count int, now time.Time) {
+	for i := 0; i < count; i++ {
+		stats.ReceivePacket(seq+uint16(i), 0, now, 0) //nolint:gosec // G115
+	}
+}
+
+func reportedSSRCs(pkt CompoundPacket) []uint32 {
+	var ssrcs []uint32
+	for _, p := range pkt {
+		var reports []ReceptionReport
+		switch report := p.(type) {
+		case *SenderReport:
+			reports = report.Reports
+		case *ReceiverReport:
+			reports = report.Reports
+		}
+		for _, r := range reports {
+			ssrcs = append(ssrcs, r.SSRC)
+		}
+	}
+
+	return ssrcs
+}
+
+func TestReporterReceiverReport(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	var out bytes.Buffer
+	reporter := NewReporter(0x1, "me@example.com", &out)
+	reporter.Name = "Me"
+	reporter.Tool = "pion"
+
+	alice := NewReceiverStats(0xA)
+	bob := NewReceiverStats(0xB)
+	reporter.AddReceiver(alice)
+	reporter.AddReceiver(bob)
+	receiveSequence(alice, 100, 10, now)
+
+	n, err := reporter.Send(now)
+	assert.NoError(t, err)
+	assert.Equal(t, out.Len(), n)
+
+	var pkt CompoundPacket
+	assert.NoError(t, pkt.Unmarshal(out.Bytes()))
+	assert.Len(t, pkt, 2)
+	rr, ok := pkt[0].(*ReceiverReport)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(0x1), rr.SSRC)
+	// Bob did not send anything.
+	assert.Len(t, rr.Reports, 1)
+	assert.Equal(t, uint32(0xA), rr.Reports[0].SSRC)
+	assert.Equal(t, &SourceDescription{Chunks: []SourceDescriptionChunk{{
+		Source: 0x1,
+		Items: []SourceDescriptionItem{
+			{Type: SDESCNAME, Text: "me@example.com"},
+			{Type: SDESName, Text: "Me"},
+			{Type: SDESTool, Text: "pion"},
+		},
+	}}}, pkt[1])
+
+	// Alice is not reported again until she sends more packets.
+	report, err := reporter.Report(now)
+	assert.NoError(t, err)
+	assert.Empty(t, reportedSSRCs(report))
+	receiveSequence(alice, 110, 1, now)
+	receiveSequence(bob, 1, 3, now)
+	report, err = reporter.Report(now)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{0xA, 0xB}, reportedSSRCs(report))
+
+	reporter.RemoveReceiver(0xA)
+	receiveSequence(alice, 111, 1, now)
+	report, err = reporter.Report(now)
+	assert.NoError(t, err)
+	assert.Empty(t, reportedSSRCs(report))
+}
+
+func TestReporterSenderReport(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	reporter := NewReporter(0x1, "me@example.com", &bytes.Buffer{})
+	reporter.Sender = NewSenderStats(0x1, 90000)
+
+	report, err := reporter.Report(now)
+	assert.NoError(t, err)
+	assert.IsType(t, &ReceiverReport{}, report[0])
+
+	reporter.Sender.SendPacket(1000, 100, now)
+	report, err = reporter.Report(now)
+	assert.NoError(t, err)
+	sr, ok := report[0].(*SenderReport)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(0x1), sr.SSRC)
+	assert.Equal(t, uint32(1), sr.PacketCount)
+	assert.Equal(t, uint32(100), sr.OctetCount)
+
+	// Nothing was sent since the last report.
+	report, err = reporter.Report(now)
+	assert.NoError(t, err)
+	assert.IsType(t, &ReceiverReport{}, report[0])
+}
+
+func TestReporterManyReceivers(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	reporter := NewReporter(0x1, "me@example.com", &bytes.Buffer{})
+	reporter.Sender = NewSenderStats(0x1, 90000)
+	reporter.Sender.SendPacket(1000, 100, now)
+
+	for ssrc := uint32(0x100); ssrc < 0x100+40; ssrc++ {
+		stats := NewReceiverStats(ssrc)
+		receiveSequence(stats, 0, 5, now)
+		reporter.AddReceiver(stats)
+	}
+
+	report, err := reporter.Report(now)
+	assert.NoError(t, err)
+	assert.Len(t, report, 3)
+	assert.Len(t, report[0].(*SenderReport).Reports, 31)           //nolint:forcetypeassert
+	assert.Len(t, report[1].(*ReceiverReport).Reports, 9)          //nolint:forcetypeassert
+	assert.Equal(t, uint32(0x1), report[1].(*ReceiverReport).SSRC) //nolint:forcetypeassert
+	assert.NoError(t, report.Validate())
+	assert.Len(t, reportedSSRCs(report), 40)
+}
+
+func TestReporterRoundRobin(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	reporter := NewReporter(0x1, "me@example.com", &bytes.Buffer{})
+	// An empty ReceiverReport and the SourceDescription take 36 octets,
+	// leaving room for three report blocks.
+	reporter.MTU = 36 + 3*receptionReportLength
+
+	receivers := make([]*ReceiverStats, 5)
+	for i := range receivers {
+		receivers[i] = NewReceiverStats(uint32(i + 1)) //nolint:gosec // G115
+		reporter.AddReceiver(receivers[i])
+	}
+
+	var seq uint16
+	for _, want := range [][]uint32{
+		{1, 2, 3},
+		{4, 5, 1},
+		{2, 3, 4},
+		{5, 1, 2},
+	} {
+		for _, stats := range receivers {
+			receiveSequence(stats, seq, 2, now)
+		}
+		seq += 2
+
+		report, err := reporter.Report(now)
+		assert.NoError(t, err)
+		assert.Equal(t, want, reportedSSRCs(report))
+		raw, err := report.Marshal()
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(raw), reporter.MTU)
+	}
+
+	reporter.MTU = 20
+	_, err := reporter.Report(now)
+	assert.ErrorIs(t, err, errPacketTooLarge)
+}
+
+func TestReporterExtendedReport(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	reporter := NewReporter(0x1, "me@example.com", &bytes.Buffer{})
+	reporter.AddExtendedReport(func(now time.Time) []ReportBlock {
+		return []ReportBlock{&ReceiverReferenceTimeReportBlock{NTPTimestamp: toNTPTime(now)}}
+	})
+	reporter.AddExtendedReport(func(time.Time) []ReportBlock {
+		return nil
+	})
+
+	report, err := reporter.Report(now)
+	assert.NoError(t, err)
+	assert.Len(t, report, 3)
+
+	raw, err := report.Marshal()
+	assert.NoError(t, err)
+	var decoded CompoundPacket
+	assert.NoError(t, decoded.Unmarshal(raw))
+	xr, ok := decoded[2].(*ExtendedReport)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(0x1), xr.SenderSSRC)
+	assert.Len(t, xr.Reports, 1)
+	rrt, ok := xr.Reports[0].(*ReceiverReferenceTimeReportBlock)
+	assert.True(t, ok)
+	assert.Equal(t, toNTPTime(now), rrt.NTPTimestamp)
+}
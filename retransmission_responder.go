@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"time"
+)
+
+// Defaults of a RetransmissionResponder.
+const (
+	defaultRetransmissionHistorySize = 512
+	defaultRetransmissionRTT         = 100 * time.Millisecond
+	retransmissionRateWindow         = time.Second
+	// rtxHeaderLength is the length of the original sequence number
+	// prepended to the payload of a retransmission, RFC 4588 Section 4.
+	rtxHeaderLength = 2
+)
+
+// Retransmission is an RTP packet to resend in response to a NACK.
+type Retransmission struct {
+	// SSRC and SequenceNumber to send the packet with. They are those of
+	// the original packet, or of the RTX stream if one is mapped.
+	SSRC           uint32
+	SequenceNumber uint16
+	// MediaSSRC and OriginalSequenceNumber identify the original packet.
+	MediaSSRC              uint32
+	OriginalSequenceNumber uint16
+	// Packet is the packet recorded with SentPacket.
+	Packet []byte
+}
+
+// RTX reports whether the packet is to be sent on an RTX stream, in which
+// case its payload must be prefixed with OriginalSequenceNumber as specified
+// by RFC 4588 Section 4.
+func (r Retransmission) RTX() bool {
+	return r.SSRC != r.MediaSSRC
+}
+
+type sentPacket struct {
+	valid         bool
+	seq           uint16
+	packet        []byte
+	lastResent    time.Time
+	hasBeenResent bool
+}
+
+type rtxStream struct {
+	ssrc uint32
+	seq  uint16
+}
+
+type sentBytes struct {
+	at   time.Time
+	size int
+}
+
+// RetransmissionResponder answers the TransportLayerNacks received by a
+// media sender. It keeps a bounded history of the last HistorySize packets
+// sent on each media source and returns the ones requested that are still
+// in it. A packet is not resent again within RTT of its last retransmission,
+// and retransmissions exceeding MaxBitrate are dropped.
+//
+// Media sources mapped with SetRTX are retransmitted on an RFC 4588 RTX
+// stream with its own SSRC and sequence number space.
+type RetransmissionResponder struct {
+	// HistorySize is the number of packets remembered per media source. It
+	// must be set before the first packet is recorded, and is rounded up to
+	// a power of two, at most 65536, so that the history stays contiguous
+	// when sequence numbers wrap.
+	HistorySize int
+	// RTT is the round-trip time, during which duplicate requests for a
+	// packet are ignored.
+	RTT time.Duration
+	// MaxBitrate is the retransmission budget in bits per second, measured
+	// over one second. 0 means unlimited.
+	MaxBitrate uint64
+
+	history map[uint32][]sentPacket
+	rtx     map[uint32]*rtxStream
+	sent    []sentBytes
+}
+
+// NewRetransmissionResponder creates a RetransmissionResponder with the
+// default history size and RTT and no bitrate limit.
+func NewRetransmissionResponder() *RetransmissionResponder {
+	return &RetransmissionResponder{
+		HistorySize: defaultRetransmissionHistorySize,
+		RTT:         defaultRetransmissionRTT,
+		history:     map[uint32][]sentPacket{},
+		rtx:         map[uint32]*rtxStream{},
+	}
+}
+
+// SetRTX maps the media source mediaSSRC to the RTX stream rtxSSRC, whose
+// first packet will carry sequence number firstSequenceNumber.
+func (r *RetransmissionResponder) SetRTX(mediaSSRC, rtxSSRC uint32, firstSequenceNumber uint16) {
+	r.rtx[mediaSSRC] = &rtxStream{ssrc: rtxSSRC, seq: firstSequenceNumber}
+}
+
+// SentPacket records the RTP packet with sequence number seq sent on the
+// media source ssrc. The packet is kept as is and must not be modified
+// afterwards.
+func (r *RetransmissionResponder) SentPacket(ssrc uint32, seq uint16, packet []byte) {
+	history, ok := r.history[ssrc]
+	if !ok {
+		size := 1
+		for size < r.HistorySize && size < 1<<16 {
+			size <<= 1
+		}
+		history = make([]sentPacket, size)
+		r.history[ssrc] = history
+	}
+	history[int(seq)%len(history)] = sentPacket{valid: true, seq: seq, packet: packet}
+}
+
+// Forget drops the history of the media source ssrc and its RTX mapping.
+func (r *RetransmissionResponder) Forget(ssrc uint32) {
+	delete(r.history, ssrc)
+	delete(r.rtx, ssrc)
+}
+
+// Receive processes the packets pkts received at time now, as returned by
+// Unmarshal, and returns the packets to retransmit in the order they were
+// requested.
+func (r *RetransmissionResponder) Receive(pkts []Packet, now time.Time) []Retransmission {
+	var out []Retransmission
+	for _, pkt := range flattenPackets(pkts) {
+		nack, ok := pkt.(*TransportLayerNack)
+		if !ok {
+			continue
+		}
+		history, ok := r.history[nack.MediaSSRC]
+		if !ok {
+			continue
+		}
+		for _, seq := range SequenceNumbersFromNacks(nack) {
+			sent := &history[int(seq)%len(history)]
+			if !sent.valid || sent.seq != seq {
+				continue
+			}
+			if sent.hasBeenResent && now.Sub(sent.lastResent) < r.RTT {
+				continue
+			}
+
+			retransmission := Retransmission{
+				SSRC:                   nack.MediaSSRC,
+				SequenceNumber:         seq,
+				MediaSSRC:              nack.MediaSSRC,
+				OriginalSequenceNumber: seq,
+				Packet:                 sent.packet,
+			}
+			size := len(sent.packet)
+			stream, isRTX := r.rtx[nack.MediaSSRC]
+			if isRTX {
+				size += rtxHeaderLength
+			}
+			if !r.spend(size, now) {
+				continue
+			}
+			if isRTX {
+				retransmission.SSRC = stream.ssrc
+				retransmission.SequenceNumber = stream.seq
+				stream.seq++
+			}
+
+			sent.hasBeenResent = true
+			sent.lastResent = now
+			out = append(out, retransmission)
+		}
+	}
+
+	return out
+}
+
+// spend reports whether size bytes can be retransmitted at time now within
+// MaxBitrate, and accounts for them if so.
+func (r *RetransmissionResponder) spend(size int, now time.Time) bool {
+	if r.MaxBitrate == 0 {
+		return true
+	}
+
+	expired := 0
+	total := 0
+	for i, sent := range r.sent {
+		if now.Sub(sent.at) >= retransmissionRateWindow {
+			expired = i + 1
+
+			continue
+		}
+		total += sent.size
+	}
+	r.sent = r.sent[expired:]
+
+	budget := r.MaxBitrate * uint64(retransmissionRateWindow/time.Millisecond) / 8000
+	if uint64(total+size) > budget { //nolint:gosec // G115, sizes are positive
+		return false
+	}
+	r.sent = append(r.sent, sentBytes{at: now, size: size})
+
+	return true
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func retransmittedSequenceNumbers(retransmissions []Retransmission) []uint16 {
+	var seqs []uint16
+	for _, r := range retransmissions {
+		seqs = append(seqs, r.OriginalSequenceNumber)
+	}
+
+	return seqs
+}
+
+func TestRetransmissionResponder(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	responder := NewRetransmissionResponder()
+	responder.HistorySize = 8
+
+	for seq := uint16(65530); seq != 6; seq++ {
+		responder.SentPacket(0xA, seq, []byte{byte(seq)})
+	}
+
+	// Packets that left the history or were never sent are not resent, and
+	// NACKs for other streams are ignored.
+	out := responder.Receive([]Packet{
+		&CompoundPacket{
+			&ReceiverReport{SSRC: 0x1},
+			&TransportLayerNack{SenderSSRC: 0x1, MediaSSRC: 0xA, Nacks: NackPairsFromSequenceNumbers(
+				[]uint16{65530, 65534, 65535, 0, 5, 6},
+			)},
+		},
+		&TransportLayerNack{SenderSSRC: 0x1, MediaSSRC: 0xB, Nacks: []NackPair{{PacketID: 1}}},
+	}, now)
+	assert.Equal(t, []uint16{65534, 65535, 0, 5}, retransmittedSequenceNumbers(out))
+	assert.Equal(t, Retransmission{
+		SSRC:                   0xA,
+		SequenceNumber:         65534,
+		MediaSSRC:              0xA,
+		OriginalSequenceNumber: 65534,
+		Packet:                 []byte{0xFE},
+	}, out[0])
+	assert.False(t, out[0].RTX())
+
+	// Duplicate requests within one RTT are suppressed.
+	nack := &TransportLayerNack{MediaSSRC: 0xA, Nacks: NackPairsFromSequenceNumbers([]uint16{0, 1})}
+	assert.Equal(t, []uint16{1}, retransmittedSequenceNumbers(responder.Receive([]Packet{nack}, now)))
+	assert.Empty(t, responder.Receive([]Packet{nack}, now.Add(50*time.Millisecond)))
+	assert.Equal(t, []uint16{0, 1}, retransmittedSequenceNumbers(responder.Receive([]Packet{nack}, now.Add(100*time.Millisecond))))
+
+	responder.Forget(0xA)
+	assert.Empty(t, responder.Receive([]Packet{nack}, now.Add(time.Second)))
+}
+
+func TestRetransmissionResponderHistoryWrap(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	responder := NewRetransmissionResponder()
+	responder.HistorySize = 100
+
+	for seq := uint16(65400); seq != 50; seq++ {
+		responder.SentPacket(0xA, seq, []byte{byte(seq)})
+	}
+
+	// The history size is rounded up to 128, and the last 128 packets are
+	// kept across the sequence number wrap.
+	out := responder.Receive([]Packet{&TransportLayerNack{
+		MediaSSRC: 0xA,
+		Nacks:     NackPairsFromSequenceNumbers([]uint16{65457, 65458, 65500, 65535, 0, 49}),
+	}}, now)
+	assert.Equal(t, []uint16{65458, 65500, 65535, 0, 49}, retransmittedSequenceNumbers(out))
+}
+
+func TestRetransmissionResponderRTX(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	responder := NewRetransmissionResponder()
+	responder.SetRTX(0xA, 0xB, 65535)
+
+	for seq := uint16(10); seq < 20; seq++ {
+		responder.SentPacket(0xA, seq, []byte{byte(seq)})
+	}
+
+	out := responder.Receive([]Packet{
+		&TransportLayerNack{MediaSSRC: 0xA, Nacks: NackPairsFromSequenceNumbers([]uint16{12, 15})},
+	}, now)
+	assert.Equal(t, []Retransmission{
+		{SSRC: 0xB, SequenceNumber: 65535, MediaSSRC: 0xA, OriginalSequenceNumber: 12, Packet: []byte{12}},
+		{SSRC: 0xB, SequenceNumber: 0, MediaSSRC: 0xA, OriginalSequenceNumber: 15, Packet: []byte{15}},
+	}, out)
+	assert.True(t, out[0].RTX())
+}
+
+func TestRetransmissionResponderBudget(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	responder := NewRetransmissionResponder()
+	// 1000 bytes per second.
+	responder.MaxBitrate = 8000
+
+	for seq := uint16(0); seq < 10; seq++ {
+		responder.SentPacket(0xA, seq, make([]byte, 300))
+	}
+	nack := &TransportLayerNack{MediaSSRC: 0xA, Nacks: NackPairsFromSequenceNumbers([]uint16{0, 1, 2, 3, 4})}
+
+	assert.Equal(t, []uint16{0, 1, 2}, retransmittedSequenceNumbers(responder.Receive([]Packet{nack}, now)))
+
+	// The budget is replenished as the window slides.
+	now = now.Add(500 * time.Millisecond)
+	assert.Empty(t, responder.Receive([]Packet{nack}, now))
+	now = now.Add(500 * time.Millisecond)
+	assert.Equal(t, []uint16{0, 1, 2}, retransmittedSequenceNumbers(responder.Receive([]Packet{nack}, now)))
+
+	// RTX retransmissions account for the original sequence number.
+	responder.SetRTX(0xA, 0xB, 0)
+	now = now.Add(time.Second)
+	assert.Len(t, responder.Receive([]Packet{nack}, now), 3)
+	responder.MaxBitrate = 7200
+	now = now.Add(time.Second)
+	assert.Len(t, responder.Receive([]Packet{nack}, now), 2)
+}
@@ -4,10 +4,13 @@
 package rtcp
 
 import (
+	"cmp"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"math"
+	"slices"
+	"time"
 )
 
 // https://www.rfc-editor.org/rfc/rfc8888.html#name-rtcp-congestion-control-fee
@@ -368,3 +371,157 @@ func (b *CCFeedbackMetricBlock) unmarshal(rawPacket []byte) error {
 
 	return nil
 }
+
+// CCFeedbackResult is the feedback about one RTP packet reported by a
+// CCFeedbackReport.
+type CCFeedbackResult struct {
+	// SequenceNumber is the RTP sequence number of the packet, extended
+	// with the number of times it wrapped.
+	SequenceNumber uint32
+	// Received reports whether the packet was received.
+	Received bool
+	// Arrival is the arrival time of the packet, or the zero time if the
+	// packet was not received or its arrival time offset is over-range or
+	// unavailable.
+	Arrival time.Time
+	// ECN is the ECN marking of the received packet.
+	ECN ECN
+}
+
+// PacketResults returns the feedback about the packets of each stream
+// reported by b, received at time now, in sequence number order. Sequence
+// numbers are extended from BeginSequence of each block, so that they keep
+// increasing when they wrap within a block.
+//
+// The compact NTP Report Timestamp wraps every 65536 seconds, about 18
+// hours, so arrival times are resolved to the wall clock assuming now is
+// within 9 hours of them, as by a CCFeedbackTimeline. Arrival times before
+// and after a wrap within the report are thus continuous.
+func (b *CCFeedbackReport) PacketResults(now time.Time) map[uint32][]CCFeedbackResult {
+	return b.packetResults(compactNTPResolver(now))
+}
+
+func (b *CCFeedbackReport) packetResults(resolve func(compact uint32) time.Time) map[uint32][]CCFeedbackResult {
+	results := make(map[uint32][]CCFeedbackResult, len(b.ReportBlocks))
+	for _, block := range b.ReportBlocks {
+		results[block.MediaSSRC] = append(results[block.MediaSSRC], b.blockResults(block, resolve)...)
+	}
+
+	return results
+}
+
+func (b *CCFeedbackReport) blockResults(
+	block CCFeedbackReportBlock,
+	resolve func(compact uint32) time.Time,
+) []CCFeedbackResult {
+	results := make([]CCFeedbackResult, 0, len(block.MetricBlocks))
+	for i, metric := range block.MetricBlocks {
+		result := CCFeedbackResult{
+			SequenceNumber: uint32(block.BeginSequence) + uint32(i), //nolint:gosec // G115
+			Received:       metric.Received,
+		}
+		if metric.Received {
+			result.ECN = metric.ECN
+			if metric.ArrivalTimeOffset <= ccfbMaxArrivalTimeOffset {
+				result.Arrival = resolve(b.ReportTimestamp - uint32(metric.ArrivalTimeOffset)*compactNTPPerATO)
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// compactNTPResolver returns a function resolving compact NTP times to the
+// wall clock. The compact NTP format wraps every 65536 seconds, so the
+// times are assumed to be within 9 hours of now.
+func compactNTPResolver(now time.Time) func(compact uint32) time.Time {
+	compactNow := toCompactNTP(toNTPTime(now))
+
+	return func(compact uint32) time.Time {
+		ago := int64(int32(compactNow - compact)) //nolint:gosec // G115
+
+		return now.Add(-time.Duration(ago * int64(time.Second) >> 16))
+	}
+}
+
+// CCFeedbackTimeline merges the CCFeedbackReports received from one
+// receiver into a timeline of the packets of each stream. Successive
+// reports may cover the same packets: a packet is only added once, unless
+// it is reported as received after having been reported as lost. Sequence
+// numbers are extended across reports, and at most 16384 packets are kept
+// per stream.
+type CCFeedbackTimeline struct {
+	streams map[uint32]*ccfbTimelineStream
+}
+
+type ccfbTimelineStream struct {
+	highest int64
+	results map[int64]CCFeedbackResult
+}
+
+// NewCCFeedbackTimeline creates an empty CCFeedbackTimeline.
+func NewCCFeedbackTimeline() *CCFeedbackTimeline {
+	return &CCFeedbackTimeline{streams: map[uint32]*ccfbTimelineStream{}}
+}
+
+// Add merges report, received at time now, and returns the results it
+// added to the timeline of each stream. Arrival times are resolved to the
+// wall clock assuming now is within 9 hours of the Report Timestamp.
+func (tl *CCFeedbackTimeline) Add(report *CCFeedbackReport, now time.Time) map[uint32][]CCFeedbackResult {
+	resolve := compactNTPResolver(now)
+	added := map[uint32][]CCFeedbackResult{}
+	for _, block := range report.ReportBlocks {
+		blockResults := report.blockResults(block, resolve)
+		if len(blockResults) == 0 {
+			continue
+		}
+
+		stream, ok := tl.streams[block.MediaSSRC]
+		if !ok {
+			stream = &ccfbTimelineStream{highest: int64(block.BeginSequence), results: map[int64]CCFeedbackResult{}}
+			tl.streams[block.MediaSSRC] = stream
+		}
+		// Extend the sequence numbers relative to the highest so far.
+		begin := stream.highest + int64(int16(block.BeginSequence-uint16(stream.highest))) //nolint:gosec // G115
+		for i, result := range blockResults {
+			seq := begin + int64(i)
+			if seq < 0 || seq <= stream.highest-maxMetricBlocks {
+				continue
+			}
+			if previous, ok := stream.results[seq]; ok && (previous.Received || !result.Received) {
+				continue
+			}
+			result.SequenceNumber = uint32(seq) //nolint:gosec // G115
+			stream.results[seq] = result
+			stream.highest = max(stream.highest, seq)
+			added[block.MediaSSRC] = append(added[block.MediaSSRC], result)
+		}
+		for seq := range stream.results {
+			if seq <= stream.highest-maxMetricBlocks {
+				delete(stream.results, seq)
+			}
+		}
+	}
+
+	return added
+}
+
+// Results returns the timeline of the stream ssrc, in sequence number
+// order.
+func (tl *CCFeedbackTimeline) Results(ssrc uint32) []CCFeedbackResult {
+	stream, ok := tl.streams[ssrc]
+	if !ok {
+		return nil
+	}
+
+	results := make([]CCFeedbackResult, 0, len(stream.results))
+	for _, result := range stream.results {
+		results = append(results, result)
+	}
+	slices.SortFunc(results, func(a, b CCFeedbackResult) int {
+		return cmp.Compare(a.SequenceNumber, b.SequenceNumber)
+	})
+
+	return results
+}
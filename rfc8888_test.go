@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -445,3 +446,107 @@ func TestCCFeedbackOverflow(t *testing.T) {
 	}, bytes.Repeat([]byte{0, 0}, 0x7FFF)...))
 	assert.ErrorIs(t, err, errReportBlockLength)
 }
+
+func TestCCFeedbackReportPacketResults(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	report := &CCFeedbackReport{
+		ReportTimestamp: toCompactNTP(toNTPTime(now)),
+		ReportBlocks: []CCFeedbackReportBlock{
+			{
+				MediaSSRC:     0xA,
+				BeginSequence: 65534,
+				MetricBlocks: []CCFeedbackMetricBlock{
+					{Received: true, ECN: ECNCE, ArrivalTimeOffset: 1024},
+					{},
+					{Received: true, ECN: ECNECT0, ArrivalTimeOffset: 0x1FFE},
+					{Received: true, ECN: ECNECT0, ArrivalTimeOffset: 0x1FFF},
+				},
+			},
+			{
+				MediaSSRC:     0xB,
+				BeginSequence: 7,
+				MetricBlocks:  []CCFeedbackMetricBlock{{Received: true, ArrivalTimeOffset: 512}},
+			},
+		},
+	}
+
+	assert.Equal(t, map[uint32][]CCFeedbackResult{
+		0xA: {
+			{SequenceNumber: 65534, Received: true, Arrival: now.Add(-time.Second), ECN: ECNCE},
+			{SequenceNumber: 65535},
+			{SequenceNumber: 65536, Received: true, ECN: ECNECT0},
+			{SequenceNumber: 65537, Received: true, ECN: ECNECT0},
+		},
+		0xB: {
+			{SequenceNumber: 7, Received: true, Arrival: now.Add(-500 * time.Millisecond)},
+		},
+	}, report.PacketResults(now))
+
+	// The compact NTP time wraps at the Unix time 1700036992, between the
+	// arrivals and the Report Timestamp.
+	now = time.Unix(1700036992, 250000000)
+	report.ReportTimestamp = toCompactNTP(toNTPTime(now))
+	results := report.PacketResults(now)
+	assert.Equal(t, time.Unix(1700036991, 250000000), results[0xA][0].Arrival)
+	assert.Equal(t, time.Unix(1700036991, 750000000), results[0xB][0].Arrival)
+}
+
+func TestCCFeedbackTimeline(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	arrivals := map[uint16]time.Time{}
+	recorder := NewCCFBRecorder(0x1)
+	timeline := NewCCFeedbackTimeline()
+
+	record := func(seq uint16, arrival time.Time) {
+		arrivals[seq] = arrival
+		recorder.Record(0xA, seq, arrival, ECNECT0)
+	}
+	checkArrivals := func(results []CCFeedbackResult) {
+		for _, result := range results {
+			if !result.Received {
+				continue
+			}
+			arrival := arrivals[uint16(result.SequenceNumber)] //nolint:gosec // G115
+			// Arrival time offsets are rounded down to 1/1024 seconds.
+			assert.False(t, result.Arrival.Before(arrival))
+			assert.Less(t, result.Arrival.Sub(arrival), time.Second/1024)
+		}
+	}
+
+	record(65533, now.Add(-30*time.Millisecond))
+	record(65535, now.Add(-20*time.Millisecond))
+	added := timeline.Add(recorder.BuildReport(now), now.Add(40*time.Millisecond))
+	assert.Len(t, added[0xA], 3)
+	checkArrivals(added[0xA])
+
+	// The next report covers 65534 again, now received late, and wraps.
+	now = now.Add(100 * time.Millisecond)
+	record(65534, now.Add(-10*time.Millisecond))
+	record(1, now.Add(-5*time.Millisecond))
+	added = timeline.Add(recorder.BuildReport(now), now.Add(40*time.Millisecond))
+	var seqs []uint32
+	for _, result := range added[0xA] {
+		seqs = append(seqs, result.SequenceNumber)
+	}
+	assert.Equal(t, []uint32{65534, 65536, 65537}, seqs)
+	checkArrivals(added[0xA])
+
+	// A duplicate report adds nothing.
+	report := &CCFeedbackReport{
+		ReportTimestamp: toCompactNTP(toNTPTime(now)),
+		ReportBlocks: []CCFeedbackReportBlock{
+			{MediaSSRC: 0xA, BeginSequence: 65535, MetricBlocks: []CCFeedbackMetricBlock{{}, {}}},
+		},
+	}
+	assert.Empty(t, timeline.Add(report, now))
+
+	results := timeline.Results(0xA)
+	seqs = nil
+	for _, result := range results {
+		seqs = append(seqs, result.SequenceNumber)
+		assert.True(t, result.Received || result.SequenceNumber == 65536)
+	}
+	assert.Equal(t, []uint32{65533, 65534, 65535, 65536, 65537}, seqs)
+	checkArrivals(results)
+	assert.Nil(t, timeline.Results(0xB))
+}
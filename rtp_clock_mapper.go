@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// clockMapperDefaultMaxReports is the number of SenderReports used for the
+	// clock rate regression when RTPClockMapper.MaxReports is zero.
+	clockMapperDefaultMaxReports = 8
+
+	// clockMapperMaxDeviation is the relative deviation from the nominal clock
+	// rate above which a new SenderReport is treated as a timeline restart.
+	clockMapperMaxDeviation = 0.1
+)
+
+type clockMapperPoint struct {
+	ntp uint64
+	rtp int64
+}
+
+// RTPClockMapper maps RTP timestamps of a single synchronization source to
+// wallclock time using the NTP/RTP timestamp pairs carried by successive
+// SenderReports. The effective clock rate of the sender, and therefore its
+// drift against the nominal rate, is estimated by a least squares fit over
+// the most recent reports.
+//
+// See RFC 3550 Section 6.4.1.
+type RTPClockMapper struct {
+	// SSRC of the media source whose SenderReports are ingested.
+	SSRC uint32
+	// CNAME of the media source, as announced in a SourceDescription.
+	// Streams sharing a CNAME can be synchronized with LipSyncOffset.
+	CNAME string
+	// Nominal RTP clock rate of the stream in Hz.
+	ClockRate uint32
+	// Maximum number of SenderReports used for the regression.
+	// Zero means clockMapperDefaultMaxReports.
+	MaxReports int
+
+	points []clockMapperPoint
+}
+
+// NewRTPClockMapper creates an RTPClockMapper for the given source and nominal clock rate.
+func NewRTPClockMapper(ssrc uint32, clockRate uint32) *RTPClockMapper {
+	return &RTPClockMapper{SSRC: ssrc, ClockRate: clockRate}
+}
+
+// AddSenderReport ingests a SenderReport from the mapped source. Reports
+// which are not newer than the last one ingested are ignored. A report whose
+// RTP timestamp does not advance at roughly the nominal clock rate restarts
+// the estimation, as happens when the sender resets its RTP timeline.
+func (m *RTPClockMapper) AddSenderReport(sr *SenderReport) error {
+	if sr.SSRC != m.SSRC {
+		return errSSRCMismatch
+	}
+	if m.ClockRate == 0 {
+		return errInvalidClockRate
+	}
+
+	if len(m.points) == 0 {
+		m.points = append(m.points, clockMapperPoint{ntp: sr.NTPTime, rtp: int64(sr.RTPTime)})
+
+		return nil
+	}
+
+	last := m.points[len(m.points)-1]
+	if sr.NTPTime <= last.ntp {
+		return nil
+	}
+
+	point := clockMapperPoint{ntp: sr.NTPTime, rtp: unwrapRTPTime(last.rtp, sr.RTPTime)}
+
+	elapsed := ntpSeconds(point.ntp - last.ntp)
+	rate := float64(point.rtp-last.rtp) / elapsed
+	if math.Abs(rate-float64(m.ClockRate)) > clockMapperMaxDeviation*float64(m.ClockRate) {
+		m.points = append(m.points[:0], clockMapperPoint{ntp: sr.NTPTime, rtp: int64(sr.RTPTime)})
+
+		return nil
+	}
+
+	m.points = append(m.points, point)
+	maxReports := m.MaxReports
+	if maxReports <= 0 {
+		maxReports = clockMapperDefaultMaxReports
+	}
+	if len(m.points) > maxReports {
+		m.points = append(m.points[:0], m.points[len(m.points)-maxReports:]...)
+	}
+
+	return nil
+}
+
+// Reset discards all ingested SenderReports.
+func (m *RTPClockMapper) Reset() {
+	m.points = m.points[:0]
+}
+
+// EstimatedClockRate returns the effective clock rate of the sender in Hz,
+// measured against its NTP clock. It equals the nominal clock rate until at
+// least two SenderReports have been ingested.
+func (m *RTPClockMapper) EstimatedClockRate() float64 {
+	rate, _, _ := m.fit()
+
+	return rate
+}
+
+// Drift returns the deviation of the estimated clock rate from the nominal
+// clock rate in parts per million.
+func (m *RTPClockMapper) Drift() float64 {
+	if m.ClockRate == 0 {
+		return 0
+	}
+
+	return (m.EstimatedClockRate() - float64(m.ClockRate)) / float64(m.ClockRate) * 1e6
+}
+
+// NTPTime returns the 64-bit NTP timestamp corresponding to the given RTP
+// timestamp. RTP timestamps are unwrapped relative to the most recent
+// SenderReport, so they must lie within 2^31 ticks of it.
+func (m *RTPClockMapper) NTPTime(rtpTime uint32) (uint64, error) {
+	if len(m.points) == 0 {
+		return 0, errNoSenderReport
+	}
+	if m.ClockRate == 0 {
+		return 0, errInvalidClockRate
+	}
+
+	rate, meanNTP, meanRTP := m.fit()
+	base := m.points[0]
+	last := m.points[len(m.points)-1]
+	rtp := unwrapRTPTime(last.rtp, rtpTime)
+
+	seconds := meanNTP + float64(rtp-base.rtp-meanRTP)/rate
+
+	return base.ntp + uint64(int64(math.Round(seconds*(1<<32)))), nil //nolint:gosec // G115
+}
+
+// Time returns the wallclock time corresponding to the given RTP timestamp.
+func (m *RTPClockMapper) Time(rtpTime uint32) (time.Time, error) {
+	ntp, err := m.NTPTime(rtpTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return ntpToTime(ntp), nil
+}
+
+// fit returns the regression slope together with the mean NTP offset (in
+// seconds) and mean RTP offset (in ticks) of the ingested reports, both
+// relative to the first report.
+func (m *RTPClockMapper) fit() (rate, meanNTP float64, meanRTP int64) {
+	rate = float64(m.ClockRate)
+	if len(m.points) == 0 {
+		return rate, 0, 0
+	}
+
+	base := m.points[0]
+	n := float64(len(m.points))
+	var sumX, sumY float64
+	for _, p := range m.points {
+		sumX += ntpSeconds(p.ntp - base.ntp)
+		sumY += float64(p.rtp - base.rtp)
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var sxx, sxy float64
+	for _, p := range m.points {
+		dx := ntpSeconds(p.ntp-base.ntp) - meanX
+		dy := float64(p.rtp-base.rtp) - meanY
+		sxx += dx * dx
+		sxy += dx * dy
+	}
+	if sxx > 0 {
+		rate = sxy / sxx
+	}
+
+	// Keep the RTP mean integral so unwrapped offsets stay exact, and move
+	// the fractional part onto the NTP axis.
+	meanRTP = int64(math.Floor(meanY))
+	meanX -= (meanY - float64(meanRTP)) / rate
+
+	return rate, meanX, meanRTP
+}
+
+// LipSyncOffset returns how much later the media sampled at RTP timestamp
+// rtpB of stream b was captured compared to the media sampled at RTP
+// timestamp rtpA of stream a. A positive offset means that b must be delayed
+// by that amount to play out in sync with a. Both streams must share a CNAME,
+// since only then are their NTP clocks comparable.
+func LipSyncOffset(a *RTPClockMapper, rtpA uint32, b *RTPClockMapper, rtpB uint32) (time.Duration, error) {
+	if a.CNAME == "" || a.CNAME != b.CNAME {
+		return 0, errCNAMEMismatch
+	}
+
+	ntpA, err := a.NTPTime(rtpA)
+	if err != nil {
+		return 0, err
+	}
+	ntpB, err := b.NTPTime(rtpB)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(math.Round(ntpSeconds(ntpB-ntpA) * float64(time.Second))), nil
+}
+
+// unwrapRTPTime extends a 32-bit RTP timestamp to the value closest to the
+// reference extended timestamp.
+func unwrapRTPTime(reference int64, rtpTime uint32) int64 {
+	return reference + int64(int32(rtpTime-uint32(reference))) //nolint:gosec // G115
+}
+
+// ntpSeconds interprets the difference of two 64-bit NTP timestamps as a
+// signed number of seconds.
+func ntpSeconds(diff uint64) float64 {
+	return float64(int64(diff)) / (1 << 32) //nolint:gosec // G115
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// senderReportAt builds a SenderReport from a sender whose RTP clock runs at
+// rate Hz and reads rtpBase at wallclock time base.
+func senderReportAt(ssrc uint32, base time.Time, rtpBase uint32, rate float64, at time.Time) *SenderReport {
+	ticks := int64(at.Sub(base).Seconds() * rate)
+
+	return &SenderReport{
+		SSRC:    ssrc,
+		NTPTime: toNTPTime(at),
+		RTPTime: rtpBase + uint32(ticks), //nolint:gosec // G115
+	}
+}
+
+func TestRTPClockMapperNominal(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	mapper := NewRTPClockMapper(0x1234, 90000)
+
+	_, err := mapper.Time(0)
+	assert.ErrorIs(t, err, errNoSenderReport)
+	assert.ErrorIs(t, mapper.AddSenderReport(&SenderReport{SSRC: 0x4321}), errSSRCMismatch)
+
+	assert.NoError(t, mapper.AddSenderReport(senderReportAt(0x1234, base, 1000, 90000, base)))
+	assert.Equal(t, 90000.0, mapper.EstimatedClockRate())
+
+	got, err := mapper.Time(1000 + 45000)
+	assert.NoError(t, err)
+	assert.Equal(t, base.Add(500*time.Millisecond), got)
+
+	rtp := uint32(1000)
+	got, err = mapper.Time(rtp - 90000)
+	assert.NoError(t, err)
+	assert.Equal(t, base.Add(-time.Second), got)
+}
+
+func TestRTPClockMapperDrift(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	// The sender's clock runs 100ppm fast.
+	const rate = 90000 * (1 + 100e-6)
+
+	mapper := NewRTPClockMapper(0x1234, 90000)
+	for i := 0; i < 10; i++ {
+		at := base.Add(time.Duration(i) * 5 * time.Second)
+		assert.NoError(t, mapper.AddSenderReport(senderReportAt(0x1234, base, 0, rate, at)))
+	}
+	// A duplicate report is ignored.
+	assert.NoError(t, mapper.AddSenderReport(senderReportAt(0x1234, base, 0, rate, base.Add(45*time.Second))))
+
+	assert.InDelta(t, rate, mapper.EstimatedClockRate(), 0.01)
+	assert.InDelta(t, 100, mapper.Drift(), 0.2)
+
+	// Extrapolate one minute past the last report.
+	want := base.Add(105 * time.Second)
+	got, err := mapper.Time(uint32(105 * rate))
+	assert.NoError(t, err)
+	assert.InDelta(t, 0, got.Sub(want).Seconds(), 50e-6)
+}
+
+func TestRTPClockMapperWraparound(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	rtpBase := uint32(0xFFFFFFFF - 90000*2)
+
+	mapper := NewRTPClockMapper(0x1234, 90000)
+	for i := 0; i < 4; i++ {
+		at := base.Add(time.Duration(i) * time.Second)
+		assert.NoError(t, mapper.AddSenderReport(senderReportAt(0x1234, base, rtpBase, 90000, at)))
+	}
+	assert.InDelta(t, 90000, mapper.EstimatedClockRate(), 0.001)
+
+	// Before and after the wrap of the 32-bit timestamp.
+	for _, offset := range []time.Duration{time.Second, 2500 * time.Millisecond, 4 * time.Second} {
+		rtp := rtpBase + uint32(offset.Seconds()*90000)
+		got, err := mapper.Time(rtp)
+		assert.NoError(t, err)
+		assert.InDelta(t, 0, got.Sub(base.Add(offset)).Seconds(), 1e-6, "offset %v", offset)
+	}
+}
+
+func TestRTPClockMapperRestart(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	mapper := NewRTPClockMapper(0x1234, 48000)
+
+	assert.NoError(t, mapper.AddSenderReport(senderReportAt(0x1234, base, 0, 48000, base)))
+	assert.NoError(t, mapper.AddSenderReport(senderReportAt(0x1234, base, 0, 48000, base.Add(time.Second))))
+
+	// The sender restarts its RTP timeline at a random offset.
+	restart := base.Add(2 * time.Second)
+	assert.NoError(t, mapper.AddSenderReport(senderReportAt(0x1234, restart, 0x40000000, 48000, restart)))
+
+	got, err := mapper.Time(0x40000000 + 48000)
+	assert.NoError(t, err)
+	assert.Equal(t, restart.Add(time.Second), got)
+}
+
+func TestLipSyncOffset(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	audio := NewRTPClockMapper(0xA, 48000)
+	video := NewRTPClockMapper(0xB, 90000)
+
+	for i := 0; i < 3; i++ {
+		at := base.Add(time.Duration(i) * time.Second)
+		assert.NoError(t, audio.AddSenderReport(senderReportAt(0xA, base, 5000, 48000, at)))
+		assert.NoError(t, video.AddSenderReport(senderReportAt(0xB, base, 700000, 90000, at)))
+	}
+
+	_, err := LipSyncOffset(audio, 5000, video, 700000)
+	assert.ErrorIs(t, err, errCNAMEMismatch)
+
+	audio.CNAME = "user@example.com"
+	video.CNAME = "user@example.com"
+
+	// The video frame was captured 80ms after the audio frame.
+	offset, err := LipSyncOffset(audio, 5000+48000, video, 700000+90000+7200)
+	assert.NoError(t, err)
+	assert.Equal(t, 80*time.Millisecond, offset)
+
+	offset, err = LipSyncOffset(video, 700000+90000+7200, audio, 5000+48000)
+	assert.NoError(t, err)
+	assert.Equal(t, -80*time.Millisecond, offset)
+}
@@ -0,0 +1,383 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"time"
+)
+
+// Parameters of SCReAM, RFC 8298 Section 4.1.1.
+const (
+	defaultSCReAMQueueDelayTarget = 100 * time.Millisecond
+	defaultSCReAMMinCWND          = 3000
+	defaultSCReAMMSS              = 1200
+	screamBetaLoss                = 0.8
+	screamBetaECN                 = 0.8
+	screamGainUp                  = 1.0
+	screamGainDown                = 2.0
+	screamBytesInFlightHeadRoom   = 1.1
+	screamFastIncreaseRestart     = 5 * time.Second
+	screamBaseDelayBucket         = time.Minute
+	screamBaseDelayBuckets        = 10
+	screamMinInFlightTimeout      = time.Second
+	screamL4SGain                 = 1.0 / 16
+)
+
+type screamStream struct {
+	priority               float64
+	minBitrate, maxBitrate uint64
+	target                 float64
+}
+
+type screamInFlight struct {
+	id       RTPPacketID
+	size     int
+	sendTime time.Time
+	done     bool
+}
+
+type screamBaseDelay struct {
+	start time.Time
+	min   time.Duration
+}
+
+// SCReAM is the Self-Clocked Rate Adaptation for Multimedia congestion
+// controller of RFC 8298, fed by RFC 8888 CCFeedbackReports.
+//
+// Only RFC 8888 feedback is supported: TransportLayerCC packets are
+// ignored, and the packets sent are recorded in a SendHistory without
+// transport-wide sequence numbers, to be matched with the feedback by RTP
+// stream and sequence number.
+//
+// The congestion window grows while the queue delay, the one-way delay
+// above the lowest seen over the last ten minutes, is below
+// QueueDelayTarget, and shrinks above it. Losses, and CE marks unless L4S
+// is set, reduce the window by 20% at most once per RTT. With L4S, CE marks
+// reduce the window once per RTT in proportion to the smoothed fraction of
+// marked packets, as DCTCP does.
+//
+// The target bitrate of each stream is its share, by priority, of the rate
+// allowed by the congestion window over the smoothed RTT, as SCReAM v2
+// does, bounded by the limits of the stream.
+//
+// The controller does not run any timers; time is passed in by the caller,
+// which calls Tick periodically so that the packets whose feedback is lost
+// leave flight.
+type SCReAM struct {
+	// QueueDelayTarget is the queue delay the controller aims for.
+	QueueDelayTarget time.Duration
+	// MinCWND is the minimum congestion window, in bytes.
+	MinCWND int
+	// MSS is the maximum size of the RTP packets, in bytes.
+	MSS int
+	// L4S enables the scalable reaction to CE marks of L4S, RFC 9330.
+	L4S bool
+
+	history          *SendHistory
+	streams          map[uint32]*screamStream
+	cwnd             float64
+	bytesInFlight    int
+	maxBytesInFlight int
+	inFlight         []*screamInFlight
+	inFlightByID     map[RTPPacketID]*screamInFlight
+	srtt             time.Duration
+	queueDelay       time.Duration
+	baseDelays       []screamBaseDelay
+	lastCongestion   time.Time
+	inFastIncrease   bool
+	l4sAlpha         float64
+	l4sMarked        int
+	l4sPackets       int
+	lastL4SUpdate    time.Time
+}
+
+// NewSCReAM creates a SCReAM controller with no streams.
+func NewSCReAM() *SCReAM {
+	return &SCReAM{
+		QueueDelayTarget: defaultSCReAMQueueDelayTarget,
+		MinCWND:          defaultSCReAMMinCWND,
+		MSS:              defaultSCReAMMSS,
+		history:          NewSendHistory(),
+		streams:          map[uint32]*screamStream{},
+		cwnd:             defaultSCReAMMinCWND,
+		inFlightByID:     map[RTPPacketID]*screamInFlight{},
+		inFastIncrease:   true,
+		l4sAlpha:         1,
+	}
+}
+
+// AddStream adds the stream ssrc, sharing the congestion window with the
+// other streams in proportion to priority, with a target bitrate between
+// minBitrate and maxBitrate bits per second.
+func (s *SCReAM) AddStream(ssrc uint32, priority float64, minBitrate, maxBitrate uint64) {
+	s.streams[ssrc] = &screamStream{
+		priority:   priority,
+		minBitrate: minBitrate,
+		maxBitrate: maxBitrate,
+		target:     float64(minBitrate),
+	}
+}
+
+// RemoveStream removes the stream ssrc.
+func (s *SCReAM) RemoveStream(ssrc uint32) {
+	delete(s.streams, ssrc)
+}
+
+// CanSend reports whether a packet of size bytes can be sent, which is the
+// case while the bytes in flight are within the congestion window.
+func (s *SCReAM) CanSend(size int) bool {
+	return float64(s.bytesInFlight+size) <= s.cwnd+float64(s.MSS)
+}
+
+// SentPacket records the packet id of size bytes sent at time now.
+func (s *SCReAM) SentPacket(id RTPPacketID, size int, now time.Time) {
+	s.history.SentWithoutTransportSequence(id, size, now)
+
+	packet := &screamInFlight{id: id, size: size, sendTime: now}
+	if previous, ok := s.inFlightByID[id]; ok {
+		s.done(previous)
+	}
+	s.inFlight = append(s.inFlight, packet)
+	s.inFlightByID[id] = packet
+	s.bytesInFlight += size
+	s.maxBytesInFlight = max(s.maxBytesInFlight, s.bytesInFlight)
+}
+
+func (s *SCReAM) done(packet *screamInFlight) {
+	if packet.done {
+		return
+	}
+	packet.done = true
+	s.bytesInFlight -= packet.size
+	if s.inFlightByID[packet.id] == packet {
+		delete(s.inFlightByID, packet.id)
+	}
+}
+
+// Receive processes the CCFeedbackReports in pkts, received at time now.
+func (s *SCReAM) Receive(pkts []Packet, now time.Time) {
+	for _, pkt := range flattenPackets(pkts) {
+		if report, ok := pkt.(*CCFeedbackReport); ok {
+			s.receiveFeedback(s.history.CCFeedbackReport(report, now), now)
+		}
+	}
+}
+
+func (s *SCReAM) receiveFeedback(feedback []PacketFeedback, now time.Time) {
+	if len(feedback) == 0 {
+		return
+	}
+
+	var ackedBytes, received, lost, marked int
+	var queueDelay time.Duration
+	var latestSend time.Time
+	for _, packet := range feedback {
+		if inFlight, ok := s.inFlightByID[packet.ID]; ok {
+			s.done(inFlight)
+		}
+		if !packet.Received {
+			lost++
+
+			continue
+		}
+		ackedBytes += packet.Size
+		received++
+		if packet.ECN == ECNCE {
+			marked++
+		}
+		if packet.SendTime.After(latestSend) {
+			latestSend = packet.SendTime
+		}
+		if !packet.Arrival.IsZero() {
+			queueDelay += s.updateBaseDelay(packet.Arrival.Sub(packet.SendTime), now)
+		}
+	}
+	if received == 0 {
+		s.reduce(screamBetaLoss, now)
+		s.expireInFlight(now)
+
+		return
+	}
+	s.queueDelay = queueDelay / time.Duration(received)
+
+	// The RTT is measured from the latest packet acknowledged.
+	if rtt := now.Sub(latestSend); s.srtt == 0 {
+		s.srtt = rtt
+	} else {
+		s.srtt = (7*s.srtt + rtt) / 8
+	}
+	s.expireInFlight(now)
+
+	switch {
+	case lost > 0:
+		s.reduce(screamBetaLoss, now)
+	case marked > 0 && !s.L4S:
+		s.reduce(screamBetaECN, now)
+	case s.L4S && s.updateL4S(received, marked, now):
+	default:
+		s.increase(ackedBytes, now)
+	}
+
+	s.cwnd = min(s.cwnd, max(float64(s.MinCWND), screamBytesInFlightHeadRoom*float64(s.maxBytesInFlight)))
+	s.cwnd = max(s.cwnd, float64(s.MinCWND))
+	s.maxBytesInFlight = s.bytesInFlight
+	s.updateTargets()
+}
+
+// updateBaseDelay adds the one-way delay sample owd and returns the queue
+// delay, RFC 8298 Section 4.1.2.1. The base delay is the lowest one-way
+// delay over the last ten one-minute periods.
+func (s *SCReAM) updateBaseDelay(owd time.Duration, now time.Time) time.Duration {
+	if len(s.baseDelays) == 0 || now.Sub(s.baseDelays[len(s.baseDelays)-1].start) >= screamBaseDelayBucket {
+		s.baseDelays = append(s.baseDelays, screamBaseDelay{start: now, min: owd})
+		if len(s.baseDelays) > screamBaseDelayBuckets {
+			s.baseDelays = s.baseDelays[1:]
+		}
+	}
+	current := &s.baseDelays[len(s.baseDelays)-1]
+	current.min = min(current.min, owd)
+
+	base := current.min
+	for _, bucket := range s.baseDelays {
+		base = min(base, bucket.min)
+	}
+
+	return owd - base
+}
+
+// Tick takes out of flight the packets sent before time now whose feedback
+// is overdue, so that sending resumes when the feedback stops. As with a
+// retransmission timeout in TCP, the congestion window then restarts from
+// MinCWND.
+func (s *SCReAM) Tick(now time.Time) {
+	if s.expireInFlight(now) {
+		s.inFastIncrease = false
+		s.cwnd = float64(s.MinCWND)
+		s.lastCongestion = now
+		s.updateTargets()
+	}
+}
+
+// expireInFlight takes out of flight the packets whose feedback was lost,
+// and reports whether there were any.
+func (s *SCReAM) expireInFlight(now time.Time) bool {
+	timeout := max(2*s.srtt, screamMinInFlightTimeout)
+	expired := false
+	for len(s.inFlight) > 0 && (s.inFlight[0].done || now.Sub(s.inFlight[0].sendTime) > timeout) {
+		expired = expired || !s.inFlight[0].done
+		s.done(s.inFlight[0])
+		s.inFlight = s.inFlight[1:]
+	}
+
+	return expired
+}
+
+// reduce multiplies the congestion window by beta, at most once per RTT.
+func (s *SCReAM) reduce(beta float64, now time.Time) {
+	s.inFastIncrease = false
+	if !s.lastCongestion.IsZero() && now.Sub(s.lastCongestion) < s.srtt {
+		return
+	}
+	s.cwnd = max(s.cwnd*beta, float64(s.MinCWND))
+	s.lastCongestion = now
+}
+
+// updateL4S updates the smoothed fraction of CE marked packets once per RTT
+// and reports whether the congestion window was reduced.
+func (s *SCReAM) updateL4S(received, marked int, now time.Time) bool {
+	s.l4sPackets += received
+	s.l4sMarked += marked
+	if s.lastL4SUpdate.IsZero() {
+		s.lastL4SUpdate = now
+	}
+	if now.Sub(s.lastL4SUpdate) < s.srtt {
+		return marked > 0
+	}
+
+	fraction := float64(s.l4sMarked) / float64(s.l4sPackets)
+	s.l4sAlpha = (1-screamL4SGain)*s.l4sAlpha + screamL4SGain*fraction
+	reduced := s.l4sMarked > 0
+	if reduced {
+		s.inFastIncrease = false
+		s.cwnd = max(s.cwnd*(1-s.l4sAlpha/2), float64(s.MinCWND))
+		s.lastCongestion = now
+	}
+	s.l4sPackets = 0
+	s.l4sMarked = 0
+	s.lastL4SUpdate = now
+
+	return reduced
+}
+
+// increase adapts the congestion window to the queue delay, RFC 8298
+// Section 4.1.2.2.
+func (s *SCReAM) increase(ackedBytes int, now time.Time) {
+	if s.queueDelay > s.QueueDelayTarget/2 {
+		s.inFastIncrease = false
+	} else if !s.inFastIncrease && now.Sub(s.lastCongestion) > screamFastIncreaseRestart &&
+		s.queueDelay < s.QueueDelayTarget/4 {
+		s.inFastIncrease = true
+	}
+
+	if s.inFastIncrease {
+		s.cwnd += float64(ackedBytes)
+
+		return
+	}
+
+	offTarget := float64(s.QueueDelayTarget-s.queueDelay) / float64(s.QueueDelayTarget)
+	gain := screamGainUp
+	if offTarget < 0 {
+		// The queue delay exceeds the target.
+		gain = screamGainDown
+		s.lastCongestion = now
+	}
+	s.cwnd += gain * offTarget * float64(ackedBytes) * float64(s.MSS) / s.cwnd
+}
+
+func (s *SCReAM) updateTargets() {
+	var priorities float64
+	for _, stream := range s.streams {
+		priorities += stream.priority
+	}
+	if priorities == 0 || s.srtt <= 0 {
+		return
+	}
+
+	rate := s.cwnd * 8 / s.srtt.Seconds()
+	for _, stream := range s.streams {
+		target := rate * stream.priority / priorities
+		stream.target = min(max(target, float64(stream.minBitrate)), float64(stream.maxBitrate))
+	}
+}
+
+// CWND returns the congestion window, in bytes.
+func (s *SCReAM) CWND() int {
+	return int(s.cwnd)
+}
+
+// BytesInFlight returns the number of bytes sent and not yet acknowledged.
+func (s *SCReAM) BytesInFlight() int {
+	return s.bytesInFlight
+}
+
+// QueueDelay returns the queue delay measured by the latest feedback.
+func (s *SCReAM) QueueDelay() time.Duration {
+	return s.queueDelay
+}
+
+// SmoothedRTT returns the smoothed round-trip time.
+func (s *SCReAM) SmoothedRTT() time.Duration {
+	return s.srtt
+}
+
+// TargetBitrate returns the target bitrate of the stream ssrc, in bits per
+// second.
+func (s *SCReAM) TargetBitrate(ssrc uint32) uint64 {
+	if stream, ok := s.streams[ssrc]; ok {
+		return uint64(stream.target)
+	}
+
+	return 0
+}
@@ -0,0 +1,295 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type screamSimulation struct {
+	// Target bitrates of each stream at the end of each second.
+	targets map[uint32][]uint64
+	// Highest queueing delay of the packets sent during each second.
+	maxQueueDelay []time.Duration
+	// Number of packets marked CE.
+	marked int
+}
+
+type screamSimulationPacket struct {
+	id      RTPPacketID
+	arrival time.Time
+	ecn     ECN
+}
+
+// simulateSCReAM paces 1200 byte packets of each stream at its target
+// bitrate through link, as long as the congestion window allows. The
+// receiver sends a report every 20ms, and marks CE the packets queued for
+// more than markThreshold when it is not zero.
+func simulateSCReAM(
+	scream *SCReAM,
+	ssrcs []uint32,
+	link *bottleneck,
+	markThreshold time.Duration,
+	duration time.Duration,
+) screamSimulation {
+	const (
+		packetSize       = 1200
+		feedbackInterval = 20 * time.Millisecond
+		step             = time.Millisecond
+	)
+	start := time.Unix(1700000000, 0)
+	recorder := NewCCFBRecorder(0x1)
+	result := screamSimulation{targets: map[uint32][]uint64{}}
+	nextSend := map[uint32]time.Time{}
+	seqs := map[uint32]uint16{}
+	var inFlight []screamSimulationPacket
+	type pendingReport struct {
+		at     time.Time
+		report *CCFeedbackReport
+	}
+	var reports []pendingReport
+
+	for now := start; now.Before(start.Add(duration)); now = now.Add(step) {
+		scream.Tick(now)
+		second := int(now.Sub(start) / time.Second)
+		if len(result.maxQueueDelay) <= second {
+			result.maxQueueDelay = append(result.maxQueueDelay, 0)
+			for _, ssrc := range ssrcs {
+				result.targets[ssrc] = append(result.targets[ssrc], 0)
+			}
+		}
+
+		for len(inFlight) > 0 && !inFlight[0].arrival.After(now) {
+			packet := inFlight[0]
+			recorder.Record(packet.id.SSRC, packet.id.SequenceNumber, packet.arrival, packet.ecn)
+			inFlight = inFlight[1:]
+		}
+		if now.Sub(start)%feedbackInterval == 0 {
+			if report := recorder.BuildReport(now); report != nil {
+				reports = append(reports, pendingReport{at: now.Add(link.propagation), report: report})
+			}
+		}
+		for len(reports) > 0 && !reports[0].at.After(now) {
+			scream.Receive([]Packet{reports[0].report}, now)
+			reports = reports[1:]
+		}
+
+		for _, ssrc := range ssrcs {
+			for !nextSend[ssrc].After(now) {
+				if !scream.CanSend(packetSize) {
+					// The encoder skips the frames it cannot send.
+					nextSend[ssrc] = now.Add(step)
+
+					break
+				}
+				id := RTPPacketID{SSRC: ssrc, SequenceNumber: seqs[ssrc]}
+				seqs[ssrc]++
+				scream.SentPacket(id, packetSize, now)
+
+				arrival := link.transmit(now, packetSize)
+				queueDelay := arrival.Sub(now) - link.propagation -
+					time.Duration(float64(packetSize*8)/link.capacity(now)*float64(time.Second))
+				ecn := ECNECT1
+				if markThreshold > 0 && queueDelay > markThreshold {
+					ecn = ECNCE
+					result.marked++
+				}
+				inFlight = append(inFlight, screamSimulationPacket{id: id, arrival: arrival, ecn: ecn})
+				result.maxQueueDelay[second] = max(result.maxQueueDelay[second], queueDelay)
+
+				interval := float64(packetSize*8) / float64(scream.TargetBitrate(ssrc))
+				nextSend[ssrc] = nextSend[ssrc].Add(time.Duration(interval * float64(time.Second)))
+				if nextSend[ssrc].Before(now) {
+					nextSend[ssrc] = now
+				}
+			}
+			result.targets[ssrc][second] = scream.TargetBitrate(ssrc)
+		}
+	}
+
+	return result
+}
+
+func TestSCReAMBottleneck(t *testing.T) {
+	scream := NewSCReAM()
+	scream.AddStream(0xA, 1, 100000, 10000000)
+	link := &bottleneck{capacity: constantCapacity(2000000), propagation: 20 * time.Millisecond}
+	result := simulateSCReAM(scream, []uint32{0xA}, link, 0, 30*time.Second)
+
+	// The queue delay converges to the target.
+	for second := 10; second < 30; second++ {
+		assert.GreaterOrEqual(t, result.targets[0xA][second], uint64(1800000), "second %d", second)
+		assert.LessOrEqual(t, result.targets[0xA][second], uint64(2400000), "second %d", second)
+		assert.Less(t, result.maxQueueDelay[second], 120*time.Millisecond, "second %d", second)
+	}
+	assert.InDelta(t, 100*time.Millisecond, scream.QueueDelay(), float64(20*time.Millisecond))
+	assert.Greater(t, scream.SmoothedRTT(), 40*time.Millisecond)
+}
+
+func TestSCReAML4S(t *testing.T) {
+	scream := NewSCReAM()
+	scream.L4S = true
+	scream.AddStream(0xA, 1, 100000, 10000000)
+	link := &bottleneck{capacity: constantCapacity(2000000), propagation: 20 * time.Millisecond}
+	result := simulateSCReAM(scream, []uint32{0xA}, link, 2*time.Millisecond, 30*time.Second)
+
+	// CE marks keep the queue delay short.
+	assert.Positive(t, result.marked)
+	for second := 5; second < 30; second++ {
+		assert.GreaterOrEqual(t, result.targets[0xA][second], uint64(1500000), "second %d", second)
+		assert.LessOrEqual(t, result.targets[0xA][second], uint64(2600000), "second %d", second)
+		assert.Less(t, result.maxQueueDelay[second], 10*time.Millisecond, "second %d", second)
+	}
+}
+
+func TestSCReAMPriorities(t *testing.T) {
+	scream := NewSCReAM()
+	scream.AddStream(0xA, 1, 100000, 10000000)
+	scream.AddStream(0xB, 3, 100000, 10000000)
+	link := &bottleneck{capacity: constantCapacity(4000000), propagation: 20 * time.Millisecond}
+	result := simulateSCReAM(scream, []uint32{0xA, 0xB}, link, 0, 30*time.Second)
+
+	for second := 10; second < 30; second++ {
+		a, b := result.targets[0xA][second], result.targets[0xB][second]
+		assert.InDelta(t, 3, float64(b)/float64(a), 0.01, "second %d", second)
+		assert.GreaterOrEqual(t, a+b, uint64(3600000), "second %d", second)
+		assert.LessOrEqual(t, a+b, uint64(4800000), "second %d", second)
+	}
+
+	// Streams are bounded by their limits.
+	scream.RemoveStream(0xA)
+	scream.AddStream(0xB, 1, 100000, 500000)
+	scream.updateTargets()
+	assert.Equal(t, uint64(500000), scream.TargetBitrate(0xB))
+	assert.Zero(t, scream.TargetBitrate(0xA))
+}
+
+// screamFeedback sends count packets of 1000 bytes on the stream 0xA and
+// returns the report acknowledging them as received 20ms later, except
+// those in lost and marked CE those in marked.
+func screamFeedback(scream *SCReAM, first uint16, count int, lost, marked []uint16, now time.Time) *CCFeedbackReport {
+	recorder := NewCCFBRecorder(0x1)
+	for i := 0; i < count; i++ {
+		seq := first + uint16(i) //nolint:gosec // G115
+		scream.SentPacket(RTPPacketID{SSRC: 0xA, SequenceNumber: seq}, 1000, now)
+		ecn := ECNECT0
+		if slices.Contains(marked, seq) {
+			ecn = ECNCE
+		}
+		if !slices.Contains(lost, seq) {
+			recorder.Record(0xA, seq, now.Add(20*time.Millisecond), ecn)
+		}
+	}
+
+	return recorder.BuildReport(now.Add(20 * time.Millisecond))
+}
+
+func TestSCReAMCongestion(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		l4s          bool
+		lost, marked []uint16
+		cwnd         int
+	}{
+		// The window is bounded by the bytes in flight.
+		{"FastIncrease", false, nil, nil, 11000},
+		{"Loss", false, []uint16{13}, nil, 8800},
+		{"ECN", false, nil, []uint16{13}, 8800},
+		// The smoothed fraction marked starts at 1: 15/16 + 1/16 * 1/20.
+		{"L4S", true, nil, []uint16{13}, 5826},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			now := time.Unix(1700000000, 0)
+			scream := NewSCReAM()
+			scream.L4S = test.l4s
+			scream.AddStream(0xA, 1, 10000, 10000000)
+			scream.Receive([]Packet{screamFeedback(scream, 0, 10, nil, nil, now)}, now.Add(40*time.Millisecond))
+			assert.Equal(t, 11000, scream.CWND())
+			assert.Zero(t, scream.BytesInFlight())
+
+			now = now.Add(100 * time.Millisecond)
+			scream.Receive([]Packet{screamFeedback(scream, 10, 10, test.lost, test.marked, now)}, now.Add(40*time.Millisecond))
+			assert.Equal(t, test.cwnd, scream.CWND())
+			assert.Zero(t, scream.BytesInFlight())
+		})
+	}
+}
+
+func TestSCReAMIgnoresTransportLayerCC(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	scream := NewSCReAM()
+	scream.AddStream(0xA, 1, 10000, 10000000)
+	scream.SentPacket(RTPPacketID{SSRC: 0xA, SequenceNumber: 0}, 1000, now)
+	scream.SentPacket(RTPPacketID{SSRC: 0xA, SequenceNumber: 1}, 1000, now)
+
+	recorder := NewTWCCRecorder(0x1, 0xA)
+	recorder.Record(0, now.Add(20*time.Millisecond))
+	scream.Receive(recorder.BuildFeedbackPackets(), now.Add(40*time.Millisecond))
+	assert.Equal(t, 2000, scream.BytesInFlight())
+}
+
+func TestSCReAMFeedbackLoss(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		allLost bool
+	}{
+		{"FeedbackStops", false},
+		{"AllLost", true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			const packetSize = 1200
+			start := time.Unix(1700000000, 0)
+			scream := NewSCReAM()
+			scream.AddStream(0xA, 1, 100000, 10000000)
+			recorder := NewCCFBRecorder(0x1)
+			var unreported []uint16
+			var seq uint16
+			sent := make([]int, 5)
+
+			for now := start; now.Before(start.Add(5 * time.Second)); now = now.Add(time.Millisecond) {
+				scream.Tick(now)
+				for scream.CanSend(packetSize) {
+					scream.SentPacket(RTPPacketID{SSRC: 0xA, SequenceNumber: seq}, packetSize, now)
+					unreported = append(unreported, seq)
+					seq++
+					sent[now.Sub(start)/time.Second]++
+				}
+				if now.Sub(start)%(20*time.Millisecond) != 0 || len(unreported) == 0 {
+					continue
+				}
+
+				// The feedback is received after one second, then it
+				// stops or reports all packets as lost.
+				switch {
+				case now.Sub(start) < time.Second:
+					for _, s := range unreported {
+						recorder.Record(0xA, s, now, ECNECT0)
+					}
+					scream.Receive([]Packet{recorder.BuildReport(now)}, now)
+				case test.allLost:
+					scream.Receive([]Packet{&CCFeedbackReport{
+						SenderSSRC:      0x1,
+						ReportTimestamp: toCompactNTP(toNTPTime(now)),
+						ReportBlocks: []CCFeedbackReportBlock{{
+							MediaSSRC:     0xA,
+							BeginSequence: unreported[0],
+							MetricBlocks:  make([]CCFeedbackMetricBlock, len(unreported)),
+						}},
+					}}, now)
+				}
+				unreported = unreported[:0]
+			}
+
+			// Packets keep being sent once the packets in flight expire.
+			for second, count := range sent {
+				assert.Positive(t, count, "second %d", second)
+			}
+			assert.Equal(t, scream.MinCWND, scream.CWND())
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"time"
+)
+
+const defaultSendHistoryMaxAge = 10 * time.Second
+
+// PacketFeedback is the feedback about one sent packet, as used by
+// congestion controllers.
+type PacketFeedback struct {
+	// ID is the packet the feedback is about.
+	ID RTPPacketID
+	// SendTime is the time the packet was sent, on the clock of the sender.
+	SendTime time.Time
+	// Size is the size of the packet in bytes.
+	Size int
+	// Received reports whether the packet was received.
+	Received bool
+	// Arrival is the arrival time of the packet on the clock of the
+	// receiver, or the zero time if it was not received or is unknown.
+	Arrival time.Time
+	// ECN is the ECN marking of the received packet, only reported by
+	// CCFeedbackReports.
+	ECN ECN
+}
+
+type sentPacketRecord struct {
+	transportSeq    uint16
+	hasTransportSeq bool
+	id              RTPPacketID
+	size            int
+	sendTime        time.Time
+	acked           bool
+}
+
+// SendHistory remembers the packets sent for MaxAge, to match them with
+// the TransportLayerCC or CCFeedbackReport feedback about them. A packet is
+// reported once it is received; packets reported as lost are kept, in case
+// later feedback reports them as received.
+type SendHistory struct {
+	// MaxAge is how long packets are remembered.
+	MaxAge time.Duration
+
+	sent        []*sentPacketRecord
+	byTransport map[uint16]*sentPacketRecord
+	byPacket    map[RTPPacketID]*sentPacketRecord
+	unwrapper   TWCCUnwrapper
+	timeline    *CCFeedbackTimeline
+}
+
+// NewSendHistory creates an empty SendHistory.
+func NewSendHistory() *SendHistory {
+	return &SendHistory{
+		MaxAge:      defaultSendHistoryMaxAge,
+		byTransport: map[uint16]*sentPacketRecord{},
+		byPacket:    map[RTPPacketID]*sentPacketRecord{},
+		timeline:    NewCCFeedbackTimeline(),
+	}
+}
+
+// Sent records the packet id of size bytes, sent at time sendTime with the
+// transport-wide sequence number transportSeq. Only transportSeq is used
+// with TransportLayerCC feedback, and only id with CCFeedbackReports.
+func (h *SendHistory) Sent(transportSeq uint16, id RTPPacketID, size int, sendTime time.Time) {
+	record := &sentPacketRecord{
+		transportSeq:    transportSeq,
+		hasTransportSeq: true,
+		id:              id,
+		size:            size,
+		sendTime:        sendTime,
+	}
+	h.add(record)
+	h.byTransport[transportSeq] = record
+}
+
+// SentWithoutTransportSequence records the packet id of size bytes, sent at
+// time sendTime without a transport-wide sequence number. It is only
+// matched with CCFeedbackReports.
+func (h *SendHistory) SentWithoutTransportSequence(id RTPPacketID, size int, sendTime time.Time) {
+	h.add(&sentPacketRecord{id: id, size: size, sendTime: sendTime})
+}
+
+func (h *SendHistory) add(record *sentPacketRecord) {
+	for len(h.sent) > 0 && (h.sent[0].acked || record.sendTime.Sub(h.sent[0].sendTime) > h.MaxAge) {
+		h.remove(h.sent[0])
+		h.sent = h.sent[1:]
+	}
+
+	h.sent = append(h.sent, record)
+	h.byPacket[record.id] = record
+}
+
+// TransportLayerCC returns the feedback about the packets reported by tcc
+// that are in the history, in the order they are reported.
+func (h *SendHistory) TransportLayerCC(tcc *TransportLayerCC) ([]PacketFeedback, error) {
+	results, err := h.unwrapper.PacketResults(tcc)
+	if err != nil {
+		return nil, err
+	}
+
+	var feedback []PacketFeedback
+	for _, result := range results {
+		if record, ok := h.byTransport[result.SequenceNumber]; ok {
+			feedback = append(feedback, h.ack(record, result.Received, result.Arrival, ECNNonECT))
+		}
+	}
+
+	return feedback, nil
+}
+
+// CCFeedbackReport returns the feedback about the packets reported by
+// report, received at time now, that are in the history, in the order they
+// are reported.
+func (h *SendHistory) CCFeedbackReport(report *CCFeedbackReport, now time.Time) []PacketFeedback {
+	var feedback []PacketFeedback
+	for _, block := range report.ReportBlocks {
+		// The timeline drops the packets already reported.
+		for _, result := range h.timeline.Add(&CCFeedbackReport{
+			SenderSSRC:      report.SenderSSRC,
+			ReportTimestamp: report.ReportTimestamp,
+			ReportBlocks:    []CCFeedbackReportBlock{block},
+		}, now)[block.MediaSSRC] {
+			id := RTPPacketID{SSRC: block.MediaSSRC, SequenceNumber: uint16(result.SequenceNumber)} //nolint:gosec // G115
+			if record, ok := h.byPacket[id]; ok {
+				feedback = append(feedback, h.ack(record, result.Received, result.Arrival, result.ECN))
+			}
+		}
+	}
+
+	return feedback
+}
+
+func (h *SendHistory) ack(record *sentPacketRecord, received bool, arrival time.Time, ecn ECN) PacketFeedback {
+	if received {
+		record.acked = true
+		h.remove(record)
+	}
+
+	return PacketFeedback{
+		ID:       record.id,
+		SendTime: record.sendTime,
+		Size:     record.size,
+		Received: received,
+		Arrival:  arrival,
+		ECN:      ecn,
+	}
+}
+
+func (h *SendHistory) remove(record *sentPacketRecord) {
+	if record.hasTransportSeq && h.byTransport[record.transportSeq] == record {
+		delete(h.byTransport, record.transportSeq)
+	}
+	if h.byPacket[record.id] == record {
+		delete(h.byPacket, record.id)
+	}
+}
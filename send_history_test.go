@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendHistoryTransportLayerCC(t *testing.T) {
+	sendTime := time.Unix(1700000000, 0)
+	arrival := time.Unix(1800000000, 0)
+	history := NewSendHistory()
+	recorder := NewTWCCRecorder(0x1, 0xA)
+	for seq := uint16(0); seq < 5; seq++ {
+		offset := time.Duration(seq) * 10 * time.Millisecond
+		history.Sent(seq, RTPPacketID{SSRC: 0xA, SequenceNumber: 100 + seq}, 1000+int(seq), sendTime.Add(offset))
+		if seq != 2 {
+			recorder.Record(seq, arrival.Add(offset))
+		}
+	}
+
+	feedback, err := history.TransportLayerCC(recorder.BuildFeedbackPackets()[0].(*TransportLayerCC)) //nolint:forcetypeassert
+	assert.NoError(t, err)
+	assert.Len(t, feedback, 5)
+	for i, packet := range feedback {
+		assert.Equal(t, sendTime.Add(time.Duration(i)*10*time.Millisecond), packet.SendTime)
+		assert.Equal(t, 1000+i, packet.Size)
+		assert.Equal(t, i != 2, packet.Received)
+	}
+	assert.Equal(t, RTPPacketID{SSRC: 0xA, SequenceNumber: 102}, feedback[2].ID)
+	assert.True(t, feedback[2].Arrival.IsZero())
+	assert.Equal(t, 40*time.Millisecond, feedback[4].Arrival.Sub(feedback[0].Arrival))
+
+	// The lost packet is reported again once received, and the packets
+	// already reported are not.
+	recorder.Record(2, arrival.Add(50*time.Millisecond))
+	tcc := recorder.BuildFeedbackPackets()[0].(*TransportLayerCC) //nolint:forcetypeassert
+	feedback, err = history.TransportLayerCC(tcc)
+	assert.NoError(t, err)
+	assert.Len(t, feedback, 1)
+	assert.Equal(t, sendTime.Add(20*time.Millisecond), feedback[0].SendTime)
+	assert.True(t, feedback[0].Received)
+
+	feedback, err = history.TransportLayerCC(tcc)
+	assert.NoError(t, err)
+	assert.Empty(t, feedback)
+}
+
+func TestSendHistoryCCFeedbackReport(t *testing.T) {
+	sendTime := time.Unix(1700000000, 0)
+	now := time.Unix(1800000000, 0)
+	history := NewSendHistory()
+	recorder := NewCCFBRecorder(0x1)
+	for seq := uint16(0); seq < 4; seq++ {
+		offset := time.Duration(seq) * 10 * time.Millisecond
+		history.Sent(seq, RTPPacketID{SSRC: 0xA, SequenceNumber: 65534 + seq}, 1000, sendTime.Add(offset))
+		if seq != 1 {
+			recorder.Record(0xA, 65534+seq, now.Add(offset), ECNECT0)
+		}
+	}
+	// Packets not in the history are ignored.
+	recorder.Record(0xB, 1, now, ECNNonECT)
+
+	feedback := history.CCFeedbackReport(recorder.BuildReport(now.Add(time.Second)), now.Add(time.Second))
+	assert.Len(t, feedback, 4)
+	for i, packet := range feedback {
+		assert.Equal(t, sendTime.Add(time.Duration(i)*10*time.Millisecond), packet.SendTime)
+		assert.Equal(t, i != 1, packet.Received)
+	}
+	assert.Equal(t, ECNECT0, feedback[0].ECN)
+	assert.Equal(t, 30*time.Millisecond, feedback[3].Arrival.Sub(feedback[0].Arrival).Round(time.Millisecond))
+
+	recorder.Record(0xA, 65535, now.Add(40*time.Millisecond), ECNCE)
+	feedback = history.CCFeedbackReport(recorder.BuildReport(now.Add(2*time.Second)), now.Add(2*time.Second))
+	assert.Len(t, feedback, 1)
+	assert.Equal(t, sendTime.Add(10*time.Millisecond), feedback[0].SendTime)
+	assert.Equal(t, ECNCE, feedback[0].ECN)
+}
+
+func TestSendHistoryMaxAge(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	history := NewSendHistory()
+	history.MaxAge = time.Second
+	history.Sent(0, RTPPacketID{SSRC: 0xA, SequenceNumber: 0}, 1000, now)
+	history.Sent(1, RTPPacketID{SSRC: 0xA, SequenceNumber: 1}, 1000, now.Add(2*time.Second))
+
+	recorder := NewCCFBRecorder(0x1)
+	recorder.Record(0xA, 0, now, ECNNonECT)
+	recorder.Record(0xA, 1, now, ECNNonECT)
+	feedback := history.CCFeedbackReport(recorder.BuildReport(now), now)
+	assert.Len(t, feedback, 1)
+	assert.Equal(t, now.Add(2*time.Second), feedback[0].SendTime)
+}
+
+func TestSendHistoryWithoutTransportSequence(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	history := NewSendHistory()
+	history.Sent(0, RTPPacketID{SSRC: 0xA, SequenceNumber: 0}, 1000, now)
+	history.SentWithoutTransportSequence(RTPPacketID{SSRC: 0xA, SequenceNumber: 1}, 1000, now)
+	history.SentWithoutTransportSequence(RTPPacketID{SSRC: 0xA, SequenceNumber: 2}, 1000, now)
+
+	// The packets sent without transport-wide sequence number do not
+	// replace the one sent with transport-wide sequence number 0.
+	twcc := NewTWCCRecorder(0x1, 0xA)
+	twcc.Record(0, now)
+	feedback, err := history.TransportLayerCC(twcc.BuildFeedbackPackets()[0].(*TransportLayerCC)) //nolint:forcetypeassert
+	assert.NoError(t, err)
+	assert.Len(t, feedback, 1)
+	assert.Equal(t, RTPPacketID{SSRC: 0xA, SequenceNumber: 0}, feedback[0].ID)
+
+	ccfb := NewCCFBRecorder(0x1)
+	ccfb.Record(0xA, 1, now, ECNNonECT)
+	ccfb.Record(0xA, 2, now, ECNNonECT)
+	feedback = history.CCFeedbackReport(ccfb.BuildReport(now), now)
+	assert.Len(t, feedback, 2)
+	assert.Equal(t, RTPPacketID{SSRC: 0xA, SequenceNumber: 2}, feedback[1].ID)
+}
@@ -257,7 +257,7 @@ func (r SenderReport) String() string {
 
 	out += "\tSSRC    \tLost\tLastSequence\n"
 	for _, i := range r.Reports {
-		out += fmt.Sprintf("\t%x\t%d/%d\t%d\n", i.SSRC, i.FractionLost, i.TotalLost, i.LastSequenceNumber)
+		out += fmt.Sprintf("\t%x\t%d/%d\t%d\n", i.SSRC, i.FractionLost, i.CumulativeLost(), i.LastSequenceNumber)
 	}
 	out += fmt.Sprintf("\tProfile Extension Data: %v\n", r.ProfileExtensions)
 
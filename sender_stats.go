@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import "time"
+
+// SenderStats keeps the statistics of an RTP stream we send and produces the
+// SenderReports describing it. See RFC 3550 Section 6.4.1.
+type SenderStats struct {
+	// SSRC of the stream. Use SetSSRC to change it, as that also resets the
+	// packet and octet counts.
+	SSRC uint32
+	// RTP clock rate of the stream in Hz.
+	ClockRate uint32
+
+	packetCount uint32
+	octetCount  uint32
+
+	sent         bool
+	lastRTPTime  uint32
+	lastSendTime time.Time
+}
+
+// NewSenderStats creates SenderStats for the given stream.
+func NewSenderStats(ssrc uint32, clockRate uint32) *SenderStats {
+	return &SenderStats{SSRC: ssrc, ClockRate: clockRate}
+}
+
+// SendPacket records an RTP packet with the given RTP timestamp and payload
+// size in octets (excluding header and padding) that was sent at time now.
+func (s *SenderStats) SendPacket(rtpTime uint32, payloadSize int, now time.Time) {
+	s.packetCount++
+	s.octetCount += uint32(payloadSize) //nolint:gosec // G115, the counter wraps around by definition
+	s.sent = true
+	s.lastRTPTime = rtpTime
+	s.lastSendTime = now
+}
+
+// SetSSRC changes the SSRC of the stream. As required by RFC 3550 Section
+// 6.4.1 the packet and octet counts are reset when the SSRC changes.
+func (s *SenderStats) SetSSRC(ssrc uint32) {
+	if ssrc == s.SSRC {
+		return
+	}
+
+	s.SSRC = ssrc
+	s.Reset()
+}
+
+// Reset clears the packet and octet counts and forgets the last RTP timestamp sent.
+func (s *SenderStats) Reset() {
+	s.packetCount = 0
+	s.octetCount = 0
+	s.sent = false
+	s.lastRTPTime = 0
+	s.lastSendTime = time.Time{}
+}
+
+// PacketCount returns the number of RTP packets sent.
+func (s *SenderStats) PacketCount() uint32 {
+	return s.packetCount
+}
+
+// OctetCount returns the number of payload octets sent.
+func (s *SenderStats) OctetCount() uint32 {
+	return s.octetCount
+}
+
+// HasSent reports whether any packet has been sent since the last reset.
+func (s *SenderStats) HasSent() bool {
+	return s.sent
+}
+
+// RTPTime returns the RTP timestamp corresponding to time now, extrapolated
+// from the last RTP timestamp sent and the clock rate.
+func (s *SenderStats) RTPTime(now time.Time) uint32 {
+	if !s.sent {
+		return 0
+	}
+
+	return s.lastRTPTime + uint32(durationToRTPTicks(now.Sub(s.lastSendTime), s.ClockRate)) //nolint:gosec // G115
+}
+
+// Report returns a SenderReport describing the stream at time now. A
+// ReceptionReport block is attached for each of the given receivers, which
+// also starts a new reporting interval for them. At most 31 blocks fit in a
+// SenderReport, so any further ones are returned in additional
+// ReceiverReports, to be sent in the same compound packet.
+func (s *SenderStats) Report(now time.Time, receivers ...*ReceiverStats) (*SenderReport, []*ReceiverReport) {
+	report := &SenderReport{
+		SSRC:        s.SSRC,
+		NTPTime:     toNTPTime(now),
+		RTPTime:     s.RTPTime(now),
+		PacketCount: s.packetCount,
+		OctetCount:  s.octetCount,
+	}
+
+	var extra []*ReceiverReport
+	for i, receiver := range receivers {
+		block := receiver.Report(now)
+		if i < countMax {
+			report.Reports = append(report.Reports, block)
+
+			continue
+		}
+		if i%countMax == 0 {
+			extra = append(extra, &ReceiverReport{SSRC: s.SSRC})
+		}
+		extra[len(extra)-1].Reports = append(extra[len(extra)-1].Reports, block)
+	}
+
+	return report, extra
+}
+
+// durationToRTPTicks converts a duration to RTP timestamp units of the given
+// clock rate without overflowing for long durations.
+func durationToRTPTicks(d time.Duration, clockRate uint32) int64 {
+	rate := int64(clockRate)
+
+	return int64(d/time.Second)*rate + int64(d%time.Second)*rate/int64(time.Second)
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSenderStatsReport(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stats := NewSenderStats(0x1234, 90000)
+
+	assert.False(t, stats.HasSent())
+	for i := 0; i < 10; i++ {
+		stats.SendPacket(uint32(0xFFFF0000+i*3000), 1000, now) //nolint:gosec // G115
+		now = now.Add(33 * time.Millisecond)
+	}
+	assert.True(t, stats.HasSent())
+
+	// 500ms after the last packet was sent, the RTP clock has advanced
+	// by 45000 ticks and wrapped around.
+	now = now.Add(500*time.Millisecond - 33*time.Millisecond)
+	report, extra := stats.Report(now)
+
+	assert.Equal(t, &SenderReport{
+		SSRC:        0x1234,
+		NTPTime:     toNTPTime(now),
+		RTPTime:     0xFFFF0000 + 9*3000 + 45000 - 1<<32,
+		PacketCount: 10,
+		OctetCount:  10000,
+	}, report)
+	assert.Empty(t, extra)
+
+	_, err := report.Marshal()
+	assert.NoError(t, err)
+}
+
+func TestSenderStatsReceptionReports(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stats := NewSenderStats(0x1234, 48000)
+	stats.SendPacket(0, 160, now)
+
+	receiver := NewReceiverStats(0x5678)
+	for seq := uint16(0); seq < 10; seq++ {
+		if seq == 5 {
+			continue
+		}
+		receiver.ReceivePacket(seq, 0, now, 0)
+	}
+
+	report, _ := stats.Report(now, receiver)
+	assert.Equal(t, []ReceptionReport{{
+		SSRC:               0x5678,
+		FractionLost:       256 / 9,
+		TotalLost:          1,
+		LastSequenceNumber: 9,
+	}}, report.Reports)
+	assert.Equal(t, []uint32{0x5678, 0x1234}, report.DestinationSSRC())
+
+	// The reporting interval of the receiver was advanced.
+	report, _ = stats.Report(now, receiver)
+	assert.Equal(t, uint8(0), report.Reports[0].FractionLost)
+}
+
+func TestSenderStatsManyReceivers(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stats := NewSenderStats(0x1234, 48000)
+	stats.SendPacket(0, 160, now)
+
+	receivers := make([]*ReceiverStats, 70)
+	for i := range receivers {
+		receivers[i] = NewReceiverStats(uint32(i)) //nolint:gosec // G115
+		receivers[i].ReceivePacket(0, 0, now, 0)
+	}
+
+	// The blocks that do not fit in the SenderReport go in ReceiverReports.
+	report, extra := stats.Report(now, receivers...)
+	assert.Len(t, report.Reports, 31)
+	assert.Len(t, extra, 2)
+	assert.Len(t, extra[0].Reports, 31)
+	assert.Len(t, extra[1].Reports, 8)
+	_, err := Marshal([]Packet{report, extra[0], extra[1]})
+	assert.NoError(t, err)
+
+	var ssrcs []uint32
+	for _, rr := range extra {
+		assert.Equal(t, uint32(0x1234), rr.SSRC)
+		for _, block := range rr.Reports {
+			ssrcs = append(ssrcs, block.SSRC)
+		}
+	}
+	assert.Equal(t, uint32(31), ssrcs[0])
+	assert.Equal(t, uint32(69), ssrcs[len(ssrcs)-1])
+}
+
+func TestSenderStatsReset(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stats := NewSenderStats(0x1234, 90000)
+
+	stats.SendPacket(1000, 100, now)
+	stats.SendPacket(4000, 100, now)
+	assert.Equal(t, uint32(2), stats.PacketCount())
+	assert.Equal(t, uint32(200), stats.OctetCount())
+
+	// Keeping the SSRC keeps the counters.
+	stats.SetSSRC(0x1234)
+	assert.Equal(t, uint32(2), stats.PacketCount())
+
+	// A new SSRC starts from scratch.
+	stats.SetSSRC(0x4321)
+	report, _ := stats.Report(now.Add(time.Second))
+	assert.Equal(t, uint32(0x4321), report.SSRC)
+	assert.Equal(t, uint32(0), report.PacketCount)
+	assert.Equal(t, uint32(0), report.OctetCount)
+	assert.Equal(t, uint32(0), report.RTPTime)
+
+	stats.SendPacket(7000, 50, now)
+	stats.Reset()
+	assert.False(t, stats.HasSent())
+	assert.Equal(t, uint32(0), stats.OctetCount())
+}
+
+func TestDurationToRTPTicks(t *testing.T) {
+	assert.Equal(t, int64(90000), durationToRTPTicks(time.Second, 90000))
+	assert.Equal(t, int64(-4500), durationToRTPTicks(-50*time.Millisecond, 90000))
+	// Long durations do not overflow.
+	assert.Equal(t, int64(90000*3600*1000), durationToRTPTicks(1000*time.Hour, 90000))
+}
@@ -24,8 +24,11 @@ type TMMBNEntry struct {
 	// SSRC of media source this entry applies to
 	MediaSSRC uint32
 
-	// Estimated maximum bitrate
-	Bitrate float32
+	// Maximum total media bitrate, in bits per second
+	Bitrate uint64
+
+	// Measured per-packet overhead, in bytes
+	Overhead uint16
 }
 
 // Marshal encodes the TMMBN packet in binary format
@@ -69,7 +72,7 @@ func (p TMMBN) Marshal() ([]byte, error) {
 		offset := ssrcLength*2 + i*(2*ssrcLength)
 		binary.BigEndian.PutUint32(body[offset:], entry.MediaSSRC)
 
-		err = putBitrate(entry.Bitrate, body[offset+ssrcLength:])
+		err = putTMMBRTuple(entry.Bitrate, entry.Overhead, body[offset+ssrcLength:])
 		if err != nil {
 			return nil, err
 		}
@@ -100,6 +103,9 @@ func (p *TMMBN) Unmarshal(rawPacket []byte) error {
 
 	body := rawPacket[headerLength:]
 	p.SenderSSRC = binary.BigEndian.Uint32(body)
+	if binary.BigEndian.Uint32(body[ssrcLength:]) != 0 {
+		return errSSRCMustBeZero
+	}
 
 	entryCount := int((header.Length - 2) / 2)
 	p.Entries = make([]TMMBNEntry, entryCount)
@@ -108,7 +114,12 @@ func (p *TMMBN) Unmarshal(rawPacket []byte) error {
 		offset := ssrcLength*2 + i*(2*ssrcLength)
 		entry := &p.Entries[i]
 		entry.MediaSSRC = binary.BigEndian.Uint32(body[offset:])
-		entry.Bitrate = loadBitrate(body[offset+ssrcLength:])
+		bitrate, overhead, err := loadTMMBRTuple(body[offset+ssrcLength:])
+		if err != nil {
+			return err
+		}
+		entry.Bitrate = bitrate
+		entry.Overhead = overhead
 	}
 
 	return nil
@@ -132,8 +143,8 @@ func (p *TMMBN) String() string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("TMMBN from %x:\n", p.SenderSSRC))
 	for i, entry := range p.Entries {
-		unit := bitrateUnit(entry.Bitrate)
-		sb.WriteString(fmt.Sprintf(" entry %d: media=%x, bitrate=%.2f %s/s\n", i, entry.MediaSSRC, entry.Bitrate, unit))
+		sb.WriteString(fmt.Sprintf(" entry %d: media=%x, bitrate=%d b/s, overhead=%d\n",
+			i, entry.MediaSSRC, entry.Bitrate, entry.Overhead))
 	}
 	return sb.String()
 }
@@ -16,45 +16,57 @@ func TestTMMBNMarshal(t *testing.T) {
 	assert := assert.New(t)
 
 	input := TMMBN{
-		SenderSSRC: 1,
+		SenderSSRC: 0x12345678,
 		Entries: []TMMBNEntry{
 			{
-				MediaSSRC: 1215622422,
-				Bitrate:   8927168.0,
+				MediaSSRC: 0x23456789,
+				Bitrate:   312000,
+				Overhead:  0x1FE,
 			},
 		},
 	}
 
+	// kPacket of libwebrtc's modules/rtp_rtcp/source/rtcp_packet/tmmbn_unittest.cc.
 	// Expected packet structure:
 	// Header: V=2, P=0, FMT=4, PT=205, Length=4
-	// SenderSSRC: 0x00000001
+	// SenderSSRC: 0x12345678
 	// MediaSSRC: 0x00000000 (always 0 per RFC 5104)
 	// FCI Entry:
-	//   - SSRC: 0x48746ED6 (1215622422)
-	//   - Bitrate: exp=6, mantissa=139487 (0x0220DF) -> 0x1A20DF
-	//   - Overhead: 0x00
-	expected := []byte{132, 205, 0, 4, 0, 0, 0, 1, 0, 0, 0, 0, 72, 116, 237, 22, 26, 32, 223, 0}
+	//   - SSRC: 0x23456789
+	//   - MxTBR: exp=2, mantissa=78000 (0x130B0)
+	//   - Measured Overhead: 510 (0x1FE)
+	//   -> 2<<26 | 78000<<9 | 510 = 0x0A6161FE
+	expected := []byte{
+		0x84, 205, 0x00, 0x04, 0x12, 0x34, 0x56, 0x78, 0x00, 0x00, 0x00, 0x00,
+		0x23, 0x45, 0x67, 0x89, 0x0A, 0x61, 0x61, 0xFE,
+	}
 
 	output, err := input.Marshal()
 	assert.NoError(err)
 	assert.Equal(expected, output)
+
+	packet := TMMBN{}
+	assert.NoError(packet.Unmarshal(output))
+	assert.Equal(input, packet)
 }
 
 func TestTMMBNUnmarshal(t *testing.T) {
 	assert := assert.New(t)
 
-	// Real TMMBN packet with bitrate 8927168 (8.9 Mb/s)
-	input := []byte{132, 205, 0, 4, 0, 0, 0, 1, 0, 0, 0, 0, 72, 116, 237, 22, 26, 32, 223, 0}
-
-	// mantissa = []byte{26 & 3, 32, 223} = []byte{2, 32, 223} = 139487
-	// exp = 26 >> 2 = 6
-	// bitrate = 139487 * 2^6 = 139487 * 64 = 8927168 = 8.9 Mb/s
+	// kPacket of libwebrtc's modules/rtp_rtcp/source/rtcp_packet/tmmbn_unittest.cc,
+	// as encoded by libwebrtc: 312000 b/s with an overhead of 510 bytes.
+	// The packet media SSRC is 0, as RFC 5104 requires.
+	input := []byte{
+		0x84, 205, 0x00, 0x04, 0x12, 0x34, 0x56, 0x78, 0x00, 0x00, 0x00, 0x00,
+		0x23, 0x45, 0x67, 0x89, 0x0A, 0x61, 0x61, 0xFE,
+	}
 	expected := TMMBN{
-		SenderSSRC: 1,
+		SenderSSRC: 0x12345678,
 		Entries: []TMMBNEntry{
 			{
-				MediaSSRC: 1215622422,
-				Bitrate:   8927168,
+				MediaSSRC: 0x23456789,
+				Bitrate:   312000,
+				Overhead:  510,
 			},
 		},
 	}
@@ -63,90 +75,53 @@ func TestTMMBNUnmarshal(t *testing.T) {
 	err := packet.Unmarshal(input)
 	assert.NoError(err)
 	assert.Equal(expected, packet)
-}
-
-func TestTMMBNTruncate(t *testing.T) {
-	assert := assert.New(t)
-
-	input := []byte{132, 205, 0, 4, 0, 0, 0, 1, 0, 0, 0, 0, 72, 116, 237, 22, 26, 32, 223, 0}
 
-	// Make sure that we're interpreting the bitrate correctly.
-	// For the above example, we have:
-
-	// mantissa = 139487
-	// exp = 6
-	// bitrate = 8927168
-
-	packet := TMMBN{}
-	err := packet.Unmarshal(input)
-	assert.NoError(err)
-	assert.Equal(float32(8927168), packet.Entries[0].Bitrate)
-
-	// Just verify marshal produces the same input.
 	output, err := packet.Marshal()
 	assert.NoError(err)
 	assert.Equal(input, output)
+}
 
-	// If we subtract the bitrate by 1, we'll round down a lower mantissa
-	packet.Entries[0].Bitrate--
-
-	// bitrate = 8927167
-	// mantissa = 139486
-	// exp = 6
+func TestTMMBNTruncate(t *testing.T) {
+	assert := assert.New(t)
 
-	output, err = packet.Marshal()
+	// The encoded bitrate never exceeds the one notified:
+	// 8927167 is encoded with exp = 7, mantissa = 8927167 >> 7 = 69743,
+	// which decodes to 8927104.
+	packet := TMMBN{Entries: []TMMBNEntry{{MediaSSRC: 1, Bitrate: 8927167}}}
+	output, err := packet.Marshal()
 	assert.NoError(err)
-	assert.NotEqual(input, output)
-
-	// Which if we actually unmarshal again, we'll find that it's actually decreased by 64 (which is 2^exp)
-	// mantissa = 139486
-	// exp = 6
-	// bitrate = 8927104
 
 	err = packet.Unmarshal(output)
 	assert.NoError(err)
-	assert.Equal(float32(8927104), packet.Entries[0].Bitrate)
+	assert.Equal(uint64(8927104), packet.Entries[0].Bitrate)
 }
 
 func TestTMMBNOverflow(t *testing.T) {
 	assert := assert.New(t)
 
-	// Marshal a packet with the maximum possible bitrate.
+	// The maximum bitrate is encoded with exp = 47, mantissa = 0x1FFFF.
 	packet := TMMBN{
 		Entries: []TMMBNEntry{
 			{
-				Bitrate: math.MaxFloat32,
+				Bitrate: math.MaxUint64,
 			},
 		},
 	}
 
-	// mantissa = 262143 = 0x3FFFF
-	// exp = 63
-
-	expected := []byte{132, 205, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 255, 255, 255, 0}
+	expected := []byte{132, 205, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xBF, 0xFF, 0xFE, 0}
 
 	output, err := packet.Marshal()
 	assert.NoError(err)
 	assert.Equal(expected, output)
 
-	// mantissa = 262143
-	// exp = 63
-	// bitrate = 0xFFFFC00000000000
-
 	err = packet.Unmarshal(output)
 	assert.NoError(err)
-	assert.Equal(math.Float32frombits(0x67FFFFC0), packet.Entries[0].Bitrate)
+	assert.Equal(uint64(0xFFFF800000000000), packet.Entries[0].Bitrate)
 
-	// Make sure we marshal to the same result again.
-	output, err = packet.Marshal()
-	assert.NoError(err)
-	assert.Equal(expected, output)
-
-	// Finally, try unmarshalling one number higher than we used to be able to handle.
-	input := []byte{132, 205, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 188, 0, 0, 0}
+	// exp = 63, mantissa = 0x1FFFF does not fit in 64 bits.
+	input := []byte{132, 205, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xFF, 0xFF, 0xFE, 0}
 	err = packet.Unmarshal(input)
-	assert.NoError(err)
-	assert.Equal(math.Float32frombits(0x62800000), packet.Entries[0].Bitrate)
+	assert.ErrorIs(err, errBitrateOverflow)
 }
 
 func TestTMMBNMultipleEntries(t *testing.T) {
@@ -157,11 +132,13 @@ func TestTMMBNMultipleEntries(t *testing.T) {
 		Entries: []TMMBNEntry{
 			{
 				MediaSSRC: 1000,
-				Bitrate:   1000000.0,
+				Bitrate:   1000000,
+				Overhead:  40,
 			},
 			{
 				MediaSSRC: 2000,
-				Bitrate:   2000000.0,
+				Bitrate:   2000000,
+				Overhead:  60,
 			},
 		},
 	}
@@ -173,14 +150,8 @@ func TestTMMBNMultipleEntries(t *testing.T) {
 	err = packet.Unmarshal(output)
 	assert.NoError(err)
 
-	assert.Equal(input.SenderSSRC, packet.SenderSSRC)
-	assert.Equal(len(input.Entries), len(packet.Entries))
-
-	for i := range input.Entries {
-		assert.Equal(input.Entries[i].MediaSSRC, packet.Entries[i].MediaSSRC)
-		// Allow small floating point differences due to encoding/decoding
-		assert.InDelta(input.Entries[i].Bitrate, packet.Entries[i].Bitrate, 1000)
-	}
+	// Both bitrates are exactly representable.
+	assert.Equal(input, packet)
 }
 
 func TestTMMBNDestinationSSRC(t *testing.T) {
@@ -238,7 +209,8 @@ func TestTMMBNString(t *testing.T) {
 		Entries: []TMMBNEntry{
 			{
 				MediaSSRC: 0xABCDEF00,
-				Bitrate:   8927168.0,
+				Bitrate:   8927168,
+				Overhead:  40,
 			},
 		},
 	}
@@ -247,6 +219,7 @@ func TestTMMBNString(t *testing.T) {
 	assert.Contains(str, "TMMBN")
 	assert.Contains(str, "12345678")
 	assert.Contains(str, "abcdef00")
+	assert.Contains(str, "bitrate=8927168 b/s, overhead=40")
 }
 
 func TestTMMBNUnmarshalErrors(t *testing.T) {
@@ -267,4 +240,9 @@ func TestTMMBNUnmarshalErrors(t *testing.T) {
 	wrongFormat[0] = 135 // Change FMT to 7
 	err = packet.Unmarshal(wrongFormat)
 	assert.Error(err)
+
+	// Test media SSRC not 0
+	mediaSSRC := []byte{132, 205, 0, 4, 0, 0, 0, 1, 0, 0, 0, 2, 72, 116, 237, 22, 26, 32, 223, 0}
+	err = packet.Unmarshal(mediaSSRC)
+	assert.ErrorIs(err, errSSRCMustBeZero)
 }
@@ -6,6 +6,7 @@ package rtcp
 import (
 	"encoding/binary"
 	"fmt"
+	"math"
 	"strings"
 )
 
@@ -24,8 +25,54 @@ type TMMBREntry struct {
 	// SSRC of media source this entry applies to
 	MediaSSRC uint32
 
-	// Estimated maximum bitrate
-	Bitrate float32
+	// Maximum total media bitrate, in bits per second
+	Bitrate uint64
+
+	// Measured per-packet overhead, in bytes
+	Overhead uint16
+}
+
+// Layout of the TMMBR and TMMBN tuples, RFC 5104 Section 4.2.1.1.
+const (
+	tmmbrMantissaBits = 17
+	tmmbrOverheadBits = 9
+	tmmbrMaxMantissa  = 1<<tmmbrMantissaBits - 1
+	tmmbrMaxOverhead  = 1<<tmmbrOverheadBits - 1
+)
+
+// putTMMBRTuple writes the MxTBR and Measured Overhead of a TMMBR or TMMBN
+// tuple to buf. The bitrate is encoded with the smallest exponent that fits
+// the mantissa, rounding down so that the requested rate is not exceeded.
+func putTMMBRTuple(bitrate uint64, overhead uint16, buf []byte) error {
+	if overhead > tmmbrMaxOverhead {
+		return errInvalidOverhead
+	}
+
+	exp := 0
+	for bitrate>>exp > tmmbrMaxMantissa {
+		exp++
+	}
+	mantissa := uint32(bitrate >> exp) //nolint:gosec // G115
+
+	binary.BigEndian.PutUint32(buf, uint32(exp)<<(tmmbrMantissaBits+tmmbrOverheadBits)| //nolint:gosec // G115
+		mantissa<<tmmbrOverheadBits|uint32(overhead))
+
+	return nil
+}
+
+// loadTMMBRTuple reads the MxTBR and Measured Overhead of a TMMBR or TMMBN
+// tuple from buf.
+func loadTMMBRTuple(buf []byte) (bitrate uint64, overhead uint16, err error) {
+	tuple := binary.BigEndian.Uint32(buf)
+	exp := tuple >> (tmmbrMantissaBits + tmmbrOverheadBits)
+	mantissa := uint64(tuple>>tmmbrOverheadBits) & tmmbrMaxMantissa
+	overhead = uint16(tuple & tmmbrMaxOverhead) //nolint:gosec // G115
+
+	if mantissa > math.MaxUint64>>exp {
+		return 0, 0, errBitrateOverflow
+	}
+
+	return mantissa << exp, overhead, nil
 }
 
 // Marshal encodes the TMMBR packet in binary format
@@ -69,7 +116,7 @@ func (p TMMBR) Marshal() ([]byte, error) {
 		offset := ssrcLength*2 + i*(2*ssrcLength)
 		binary.BigEndian.PutUint32(body[offset:], entry.MediaSSRC)
 
-		err = putBitrate(entry.Bitrate, body[offset+ssrcLength:])
+		err = putTMMBRTuple(entry.Bitrate, entry.Overhead, body[offset+ssrcLength:])
 		if err != nil {
 			return nil, err
 		}
@@ -100,6 +147,9 @@ func (p *TMMBR) Unmarshal(rawPacket []byte) error {
 
 	body := rawPacket[headerLength:]
 	p.SenderSSRC = binary.BigEndian.Uint32(body)
+	if binary.BigEndian.Uint32(body[ssrcLength:]) != 0 {
+		return errSSRCMustBeZero
+	}
 
 	entryCount := int((header.Length - 2) / 2)
 	p.Entries = make([]TMMBREntry, entryCount)
@@ -108,7 +158,12 @@ func (p *TMMBR) Unmarshal(rawPacket []byte) error {
 		offset := ssrcLength*2 + i*(2*ssrcLength)
 		entry := &p.Entries[i]
 		entry.MediaSSRC = binary.BigEndian.Uint32(body[offset:])
-		entry.Bitrate = loadBitrate(body[offset+ssrcLength:])
+		bitrate, overhead, err := loadTMMBRTuple(body[offset+ssrcLength:])
+		if err != nil {
+			return err
+		}
+		entry.Bitrate = bitrate
+		entry.Overhead = overhead
 	}
 
 	return nil
@@ -132,8 +187,8 @@ func (p *TMMBR) String() string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("TMMBR from %x:\n", p.SenderSSRC))
 	for i, entry := range p.Entries {
-		unit := bitrateUnit(entry.Bitrate)
-		sb.WriteString(fmt.Sprintf(" entry %d: media=%x, bitrate=%.2f %s/s\n", i, entry.MediaSSRC, entry.Bitrate, unit))
+		sb.WriteString(fmt.Sprintf(" entry %d: media=%x, bitrate=%d b/s, overhead=%d\n",
+			i, entry.MediaSSRC, entry.Bitrate, entry.Overhead))
 	}
 	return sb.String()
 }
@@ -16,45 +16,57 @@ func TestTMMBRMarshal(t *testing.T) {
 	assert := assert.New(t)
 
 	input := TMMBR{
-		SenderSSRC: 1,
+		SenderSSRC: 0x12345678,
 		Entries: []TMMBREntry{
 			{
-				MediaSSRC: 1215622422,
-				Bitrate:   8927168.0,
+				MediaSSRC: 0x23456789,
+				Bitrate:   312000,
+				Overhead:  0x1FE,
 			},
 		},
 	}
 
+	// kPacket of libwebrtc's modules/rtp_rtcp/source/rtcp_packet/tmmbr_unittest.cc.
 	// Expected packet structure:
 	// Header: V=2, P=0, FMT=3, PT=205, Length=4
-	// SenderSSRC: 0x00000001
+	// SenderSSRC: 0x12345678
 	// MediaSSRC: 0x00000000 (always 0 per RFC 5104)
 	// FCI Entry:
-	//   - SSRC: 0x48746ED6 (1215622422)
-	//   - Bitrate: exp=6, mantissa=139487 (0x0220DF) -> 0x1A20DF
-	//   - Overhead: 0x00
-	expected := []byte{131, 205, 0, 4, 0, 0, 0, 1, 0, 0, 0, 0, 72, 116, 237, 22, 26, 32, 223, 0}
+	//   - SSRC: 0x23456789
+	//   - MxTBR: exp=2, mantissa=78000 (0x130B0)
+	//   - Measured Overhead: 510 (0x1FE)
+	//   -> 2<<26 | 78000<<9 | 510 = 0x0A6161FE
+	expected := []byte{
+		0x83, 205, 0x00, 0x04, 0x12, 0x34, 0x56, 0x78, 0x00, 0x00, 0x00, 0x00,
+		0x23, 0x45, 0x67, 0x89, 0x0A, 0x61, 0x61, 0xFE,
+	}
 
 	output, err := input.Marshal()
 	assert.NoError(err)
 	assert.Equal(expected, output)
+
+	packet := TMMBR{}
+	assert.NoError(packet.Unmarshal(output))
+	assert.Equal(input, packet)
 }
 
 func TestTMMBRUnmarshal(t *testing.T) {
 	assert := assert.New(t)
 
-	// Real TMMBR packet with bitrate 8927168 (8.9 Mb/s)
-	input := []byte{131, 205, 0, 4, 0, 0, 0, 1, 0, 0, 0, 0, 72, 116, 237, 22, 26, 32, 223, 0}
-
-	// mantissa = []byte{26 & 3, 32, 223} = []byte{2, 32, 223} = 139487
-	// exp = 26 >> 2 = 6
-	// bitrate = 139487 * 2^6 = 139487 * 64 = 8927168 = 8.9 Mb/s
+	// kPacket of libwebrtc's modules/rtp_rtcp/source/rtcp_packet/tmmbr_unittest.cc,
+	// as encoded by libwebrtc: 312000 b/s with an overhead of 510 bytes.
+	// The packet media SSRC is 0, as RFC 5104 requires.
+	input := []byte{
+		0x83, 205, 0x00, 0x04, 0x12, 0x34, 0x56, 0x78, 0x00, 0x00, 0x00, 0x00,
+		0x23, 0x45, 0x67, 0x89, 0x0A, 0x61, 0x61, 0xFE,
+	}
 	expected := TMMBR{
-		SenderSSRC: 1,
+		SenderSSRC: 0x12345678,
 		Entries: []TMMBREntry{
 			{
-				MediaSSRC: 1215622422,
-				Bitrate:   8927168,
+				MediaSSRC: 0x23456789,
+				Bitrate:   312000,
+				Overhead:  510,
 			},
 		},
 	}
@@ -63,90 +75,57 @@ func TestTMMBRUnmarshal(t *testing.T) {
 	err := packet.Unmarshal(input)
 	assert.NoError(err)
 	assert.Equal(expected, packet)
-}
-
-func TestTMMBRTruncate(t *testing.T) {
-	assert := assert.New(t)
 
-	input := []byte{131, 205, 0, 4, 0, 0, 0, 1, 0, 0, 0, 0, 72, 116, 237, 22, 26, 32, 223, 0}
-
-	// Make sure that we're interpreting the bitrate correctly.
-	// For the above example, we have:
-
-	// mantissa = 139487
-	// exp = 6
-	// bitrate = 8927168
-
-	packet := TMMBR{}
-	err := packet.Unmarshal(input)
-	assert.NoError(err)
-	assert.Equal(float32(8927168), packet.Entries[0].Bitrate)
-
-	// Just verify marshal produces the same input.
 	output, err := packet.Marshal()
 	assert.NoError(err)
 	assert.Equal(input, output)
-
-	// If we subtract the bitrate by 1, we'll round down a lower mantissa
-	packet.Entries[0].Bitrate--
-
-	// bitrate = 8927167
-	// mantissa = 139486
-	// exp = 6
-
-	output, err = packet.Marshal()
-	assert.NoError(err)
-	assert.NotEqual(input, output)
-
-	// Which if we actually unmarshal again, we'll find that it's actually decreased by 64 (which is 2^exp)
-	// mantissa = 139486
-	// exp = 6
-	// bitrate = 8927104
-
-	err = packet.Unmarshal(output)
-	assert.NoError(err)
-	assert.Equal(float32(8927104), packet.Entries[0].Bitrate)
 }
 
-func TestTMMBROverflow(t *testing.T) {
-	assert := assert.New(t)
-
-	// Marshal a packet with the maximum possible bitrate.
-	packet := TMMBR{
-		Entries: []TMMBREntry{
-			{
-				Bitrate: math.MaxFloat32,
-			},
-		},
+func TestTMMBRTuple(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		bitrate  uint64
+		overhead uint16
+		tuple    []byte
+		decoded  uint64
+	}{
+		{"Zero", 0, 0, []byte{0x00, 0x00, 0x00, 0x00}, 0},
+		{"LargestMantissa", 131071, 0, []byte{0x03, 0xFF, 0xFE, 0x00}, 131071},
+		{"SmallestExponent", 131072, 0, []byte{0x06, 0x00, 0x00, 0x00}, 131072},
+		// The mantissa is rounded down so as not to exceed the rate.
+		{"RoundDown", 131073, 0, []byte{0x06, 0x00, 0x00, 0x00}, 131072},
+		{"RoundDownLarge", 8927167, 0, []byte{0x1E, 0x20, 0xDE, 0x00}, 8927104},
+		{"Overhead", 1000000, 40, []byte{0x0F, 0xD0, 0x90, 0x28}, 1000000},
+		{"MaxOverhead", 0, 511, []byte{0x00, 0x00, 0x01, 0xFF}, 0},
+		// exp = 47, mantissa = 0x1FFFF
+		{"MaxUint64", math.MaxUint64, 0, []byte{0xBF, 0xFF, 0xFE, 0x00}, 0xFFFF800000000000},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			buf := make([]byte, 4)
+			assert.NoError(t, putTMMBRTuple(test.bitrate, test.overhead, buf))
+			assert.Equal(t, test.tuple, buf)
+
+			bitrate, overhead, err := loadTMMBRTuple(buf)
+			assert.NoError(t, err)
+			assert.Equal(t, test.decoded, bitrate)
+			assert.Equal(t, test.overhead, overhead)
+		})
 	}
 
-	// mantissa = 262143 = 0x3FFFF
-	// exp = 63
-
-	expected := []byte{131, 205, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 255, 255, 255, 0}
-
-	output, err := packet.Marshal()
-	assert.NoError(err)
-	assert.Equal(expected, output)
-
-	// mantissa = 262143
-	// exp = 63
-	// bitrate = 0xFFFFC00000000000
+	assert.ErrorIs(t, putTMMBRTuple(1000000, 512, make([]byte, 4)), errInvalidOverhead)
 
-	err = packet.Unmarshal(output)
-	assert.NoError(err)
-	assert.Equal(math.Float32frombits(0x67FFFFC0), packet.Entries[0].Bitrate)
-
-	// Make sure we marshal to the same result again.
-	output, err = packet.Marshal()
-	assert.NoError(err)
-	assert.Equal(expected, output)
+	// 1 * 2^63 fits in 64 bits, 2 * 2^63 does not.
+	bitrate, _, err := loadTMMBRTuple([]byte{0xFC, 0x00, 0x02, 0x00})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1)<<63, bitrate)
+	_, _, err = loadTMMBRTuple([]byte{0xFC, 0x00, 0x04, 0x00})
+	assert.ErrorIs(t, err, errBitrateOverflow)
+}
 
-	// Finally, try unmarshalling one number higher than we used to be able to handle.
-	input := []byte{131, 205, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 188, 0, 0, 0}
-	err = packet.Unmarshal(input)
-	assert.NoError(err)
-	assert.Equal(math.Float32frombits(0x62800000), packet.Entries[0].Bitrate)
+func TestTMMBRMarshalInvalidOverhead(t *testing.T) {
+	packet := TMMBR{Entries: []TMMBREntry{{MediaSSRC: 1000, Bitrate: 1000000, Overhead: 512}}}
+	_, err := packet.Marshal()
+	assert.ErrorIs(t, err, errInvalidOverhead)
 }
 
 func TestTMMBRMultipleEntries(t *testing.T) {
@@ -157,11 +136,13 @@ func TestTMMBRMultipleEntries(t *testing.T) {
 		Entries: []TMMBREntry{
 			{
 				MediaSSRC: 1000,
-				Bitrate:   1000000.0,
+				Bitrate:   1000000,
+				Overhead:  40,
 			},
 			{
 				MediaSSRC: 2000,
-				Bitrate:   2000000.0,
+				Bitrate:   2000000,
+				Overhead:  60,
 			},
 		},
 	}
@@ -173,14 +154,8 @@ func TestTMMBRMultipleEntries(t *testing.T) {
 	err = packet.Unmarshal(output)
 	assert.NoError(err)
 
-	assert.Equal(input.SenderSSRC, packet.SenderSSRC)
-	assert.Equal(len(input.Entries), len(packet.Entries))
-
-	for i := range input.Entries {
-		assert.Equal(input.Entries[i].MediaSSRC, packet.Entries[i].MediaSSRC)
-		// Allow small floating point differences due to encoding/decoding
-		assert.InDelta(input.Entries[i].Bitrate, packet.Entries[i].Bitrate, 1000)
-	}
+	// Both bitrates are exactly representable.
+	assert.Equal(input, packet)
 }
 
 func TestTMMBRDestinationSSRC(t *testing.T) {
@@ -238,7 +213,8 @@ func TestTMMBRString(t *testing.T) {
 		Entries: []TMMBREntry{
 			{
 				MediaSSRC: 0xABCDEF00,
-				Bitrate:   8927168.0,
+				Bitrate:   8927168,
+				Overhead:  40,
 			},
 		},
 	}
@@ -247,6 +223,7 @@ func TestTMMBRString(t *testing.T) {
 	assert.Contains(str, "TMMBR")
 	assert.Contains(str, "12345678")
 	assert.Contains(str, "abcdef00")
+	assert.Contains(str, "bitrate=8927168 b/s, overhead=40")
 }
 
 func TestTMMBRUnmarshalErrors(t *testing.T) {
@@ -267,4 +244,9 @@ func TestTMMBRUnmarshalErrors(t *testing.T) {
 	wrongFormat[0] = 135 // Change FMT to 7
 	err = packet.Unmarshal(wrongFormat)
 	assert.Error(err)
+
+	// Test media SSRC not 0
+	mediaSSRC := []byte{131, 205, 0, 4, 0, 0, 0, 1, 0, 0, 0, 2, 72, 116, 237, 22, 26, 32, 223, 0}
+	err = packet.Unmarshal(mediaSSRC)
+	assert.ErrorIs(err, errSSRCMustBeZero)
 }
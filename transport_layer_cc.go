@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"time"
 )
 
 // https://tools.ietf.org/html/draft-holmer-rmcat-transport-wide-cc-extensions-01#page-5
@@ -583,3 +584,100 @@ func localMin(x, y uint16) uint16 {
 
 	return y
 }
+
+// PacketResult is the feedback about one packet reported by a
+// TransportLayerCC.
+type PacketResult struct {
+	// SequenceNumber is the transport-wide sequence number of the packet.
+	SequenceNumber uint16
+	// Received reports whether the packet was received.
+	Received bool
+	// Arrival is the arrival time of the packet on the clock of the
+	// receiver, or the zero time if the packet was not received or was
+	// reported without a receive delta. The reference time 0 is taken as
+	// the Unix epoch, as done by TWCCRecorder.
+	Arrival time.Time
+}
+
+// PacketResults returns the feedback about each packet reported by t, in
+// transport-wide sequence number order. The arrival times are computed from
+// ReferenceTime, so they are only continuous across the wrap of the
+// reference time when the packets are decoded with a TWCCUnwrapper.
+func (t *TransportLayerCC) PacketResults() ([]PacketResult, error) {
+	return t.packetResults(int64(t.ReferenceTime))
+}
+
+//nolint:cyclop
+func (t *TransportLayerCC) packetResults(referenceTime int64) ([]PacketResult, error) {
+	symbols := make([]uint16, 0, t.PacketStatusCount)
+	for _, chunk := range t.PacketChunks {
+		switch chunk := chunk.(type) {
+		case *RunLengthChunk:
+			for i := uint16(0); i < chunk.RunLength; i++ {
+				symbols = append(symbols, chunk.PacketStatusSymbol)
+			}
+		case *StatusVectorChunk:
+			symbols = append(symbols, chunk.SymbolList...)
+		default:
+			return nil, errWrongChunkType
+		}
+	}
+	// Status vectors are padded with symbols beyond the status count.
+	if len(symbols) < int(t.PacketStatusCount) {
+		return nil, errPacketStatusCount
+	}
+	symbols = symbols[:t.PacketStatusCount]
+
+	results := make([]PacketResult, 0, len(symbols))
+	arrival := referenceTime * tccBaseTimeTick
+	deltas := t.RecvDeltas
+	for i, symbol := range symbols {
+		result := PacketResult{
+			SequenceNumber: t.BaseSequenceNumber + uint16(i), //nolint:gosec // G115
+			Received:       symbol != TypeTCCPacketNotReceived,
+		}
+		if symbol == TypeTCCPacketReceivedSmallDelta || symbol == TypeTCCPacketReceivedLargeDelta {
+			if len(deltas) == 0 || deltas[0].Type != symbol {
+				return nil, errRecvDeltaMismatch
+			}
+			arrival += deltas[0].Delta
+			deltas = deltas[1:]
+			result.Arrival = time.UnixMicro(arrival)
+		}
+		results = append(results, result)
+	}
+	if len(deltas) != 0 {
+		return nil, errRecvDeltaMismatch
+	}
+
+	return results, nil
+}
+
+// TWCCUnwrapper decodes consecutive TransportLayerCC packets from the same
+// receiver, keeping their arrival times continuous when the 24-bit reference
+// time wraps, every 2^24 × 64ms.
+type TWCCUnwrapper struct {
+	started       bool
+	referenceTime int64
+}
+
+// PacketResults returns the feedback about each packet reported by t, like
+// (*TransportLayerCC).PacketResults, with the reference time unwrapped
+// relative to the previous packet.
+func (u *TWCCUnwrapper) PacketResults(t *TransportLayerCC) ([]PacketResult, error) {
+	referenceTime := int64(t.ReferenceTime)
+	if u.started {
+		// Sign-extend the 24-bit difference to the previous reference time.
+		diff := int64(int32((t.ReferenceTime-uint32(u.referenceTime))<<8) >> 8) //nolint:gosec // G115
+		referenceTime = u.referenceTime + diff
+	}
+
+	results, err := t.packetResults(referenceTime)
+	if err != nil {
+		return nil, err
+	}
+	u.started = true
+	u.referenceTime = referenceTime
+
+	return results, nil
+}
@@ -5,6 +5,7 @@ package rtcp
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -1167,3 +1168,163 @@ func TestTransportLayerCC_Marshal(t *testing.T) {
 		})
 	}
 }
+
+func TestTransportLayerCCPacketResults(t *testing.T) {
+	// example2 of TestTransportLayerCCUnmarshal, which mixes small, large
+	// and missing deltas.
+	tcc := &TransportLayerCC{}
+	assert.NoError(t, tcc.Unmarshal([]byte{
+		0xaf, 0xcd, 0x0, 0x6,
+		0xfa, 0x17, 0xfa, 0x17,
+		0x19, 0x3d, 0xd8, 0xbb,
+		0x1, 0x74, 0x0, 0xe,
+		0x45, 0xb1, 0x5a, 0x40,
+		0xd8, 0x0, 0xf0, 0xff,
+		0xd0, 0x0, 0x0, 0x3,
+	}))
+	results, err := tcc.PacketResults()
+	assert.NoError(t, err)
+
+	reference := int64(4567386) * 64000
+	assert.Equal(t, []PacketResult{
+		{SequenceNumber: 372, Received: true, Arrival: time.UnixMicro(reference + 52000)},
+		{SequenceNumber: 373, Received: true, Arrival: time.UnixMicro(reference + 52000)},
+		{SequenceNumber: 374},
+		{SequenceNumber: 375},
+		{SequenceNumber: 376},
+		{SequenceNumber: 377},
+		{SequenceNumber: 378},
+		{SequenceNumber: 379, Received: true},
+		{SequenceNumber: 380},
+		{SequenceNumber: 381},
+		{SequenceNumber: 382, Received: true},
+		{SequenceNumber: 383, Received: true},
+		{SequenceNumber: 384, Received: true},
+		{SequenceNumber: 385, Received: true},
+	}, results)
+}
+
+func TestTransportLayerCCPacketResultsWraparound(t *testing.T) {
+	tcc := &TransportLayerCC{
+		BaseSequenceNumber: 65534,
+		PacketStatusCount:  4,
+		ReferenceTime:      10,
+		PacketChunks: []PacketStatusChunk{
+			&StatusVectorChunk{
+				Type:       TypeTCCStatusVectorChunk,
+				SymbolSize: TypeTCCSymbolSizeTwoBit,
+				SymbolList: []uint16{1, 2, 0, 2, 0, 0, 0},
+			},
+		},
+		RecvDeltas: []*RecvDelta{
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 1000},
+			{Type: TypeTCCPacketReceivedLargeDelta, Delta: 100000},
+			{Type: TypeTCCPacketReceivedLargeDelta, Delta: -2500},
+		},
+	}
+	results, err := tcc.PacketResults()
+	assert.NoError(t, err)
+	assert.Equal(t, []PacketResult{
+		{SequenceNumber: 65534, Received: true, Arrival: time.UnixMicro(641000)},
+		{SequenceNumber: 65535, Received: true, Arrival: time.UnixMicro(741000)},
+		{SequenceNumber: 0},
+		{SequenceNumber: 1, Received: true, Arrival: time.UnixMicro(738500)},
+	}, results)
+}
+
+func TestTransportLayerCCPacketResultsRecorder(t *testing.T) {
+	start := time.UnixMicro(1000 * 64000)
+	arrivals := map[uint16]time.Time{}
+	recorder := NewTWCCRecorder(0x1, 0x2)
+	for i := 0; i < 100; i++ {
+		seq := uint16(65500 + i) //nolint:gosec // G115
+		if i%7 == 3 {
+			continue
+		}
+		arrivals[seq] = start.Add(time.Duration(i*i) * 250 * time.Microsecond)
+		recorder.Record(seq, arrivals[seq])
+	}
+
+	pkts := recorder.BuildFeedbackPackets()
+	assert.Len(t, pkts, 1)
+	tcc, ok := pkts[0].(*TransportLayerCC)
+	assert.True(t, ok)
+	results, err := tcc.PacketResults()
+	assert.NoError(t, err)
+	assert.Len(t, results, 100)
+	for i, result := range results {
+		arrival, received := arrivals[uint16(65500+i)]          //nolint:gosec // G115
+		assert.Equal(t, uint16(65500+i), result.SequenceNumber) //nolint:gosec // G115
+		assert.Equal(t, received, result.Received)
+		assert.True(t, arrival.Equal(result.Arrival))
+	}
+}
+
+func TestTransportLayerCCPacketResultsInvalid(t *testing.T) {
+	valid := func() *TransportLayerCC {
+		return &TransportLayerCC{
+			PacketStatusCount: 2,
+			PacketChunks: []PacketStatusChunk{
+				&RunLengthChunk{PacketStatusSymbol: TypeTCCPacketReceivedSmallDelta, RunLength: 2},
+			},
+			RecvDeltas: []*RecvDelta{
+				{Type: TypeTCCPacketReceivedSmallDelta},
+				{Type: TypeTCCPacketReceivedSmallDelta},
+			},
+		}
+	}
+	_, err := valid().PacketResults()
+	assert.NoError(t, err)
+
+	tcc := valid()
+	tcc.PacketStatusCount = 3
+	_, err = tcc.PacketResults()
+	assert.ErrorIs(t, err, errPacketStatusCount)
+
+	tcc = valid()
+	tcc.RecvDeltas = tcc.RecvDeltas[:1]
+	_, err = tcc.PacketResults()
+	assert.ErrorIs(t, err, errRecvDeltaMismatch)
+
+	tcc = valid()
+	tcc.RecvDeltas = append(tcc.RecvDeltas, &RecvDelta{Type: TypeTCCPacketReceivedSmallDelta})
+	_, err = tcc.PacketResults()
+	assert.ErrorIs(t, err, errRecvDeltaMismatch)
+
+	tcc = valid()
+	tcc.RecvDeltas[1].Type = TypeTCCPacketReceivedLargeDelta
+	_, err = tcc.PacketResults()
+	assert.ErrorIs(t, err, errRecvDeltaMismatch)
+
+	tcc = valid()
+	tcc.PacketChunks = []PacketStatusChunk{nil}
+	_, err = tcc.PacketResults()
+	assert.ErrorIs(t, err, errWrongChunkType)
+}
+
+func TestTWCCUnwrapper(t *testing.T) {
+	feedback := func(referenceTime uint32) *TransportLayerCC {
+		return &TransportLayerCC{
+			PacketStatusCount: 1,
+			ReferenceTime:     referenceTime,
+			PacketChunks: []PacketStatusChunk{
+				&RunLengthChunk{PacketStatusSymbol: TypeTCCPacketReceivedSmallDelta, RunLength: 1},
+			},
+			RecvDeltas: []*RecvDelta{{Type: TypeTCCPacketReceivedSmallDelta}},
+		}
+	}
+
+	var unwrapper TWCCUnwrapper
+	var arrivals []time.Time
+	for _, referenceTime := range []uint32{0xFFFFFE, 0xFFFFFF, 0x000001, 0xFFFFFF, 0x000002} {
+		results, err := unwrapper.PacketResults(feedback(referenceTime))
+		assert.NoError(t, err)
+		arrivals = append(arrivals, results[0].Arrival)
+	}
+	for i, ticks := range []int64{0xFFFFFE, 0xFFFFFF, 0x1000001, 0xFFFFFF, 0x1000002} {
+		assert.Equal(t, time.UnixMicro(ticks*64000), arrivals[i])
+	}
+
+	_, err := unwrapper.PacketResults(&TransportLayerCC{PacketStatusCount: 1})
+	assert.ErrorIs(t, err, errPacketStatusCount)
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math"
+	"slices"
 )
 
 // PacketBitmap shouldn't be used like a normal integral,
@@ -38,11 +39,16 @@ type TransportLayerNack struct {
 
 // NackPairsFromSequenceNumbers generates a slice of NackPair from a list of SequenceNumbers
 // This handles generating the proper values for PacketID/LostPackets.
+//
+// The sequence numbers may be given in any order and contain duplicates. They are
+// ordered in serial number arithmetic (RFC 1982), so that a list spanning the
+// wraparound from 65535 to 0 is encoded in a minimal number of pairs.
 func NackPairsFromSequenceNumbers(sequenceNumbers []uint16) (pairs []NackPair) {
 	if len(sequenceNumbers) == 0 {
 		return []NackPair{}
 	}
 
+	sequenceNumbers = serialSortSequenceNumbers(sequenceNumbers)
 	nackPair := &NackPair{PacketID: sequenceNumbers[0]}
 	for i := 1; i < len(sequenceNumbers); i++ {
 		m := sequenceNumbers[i]
@@ -61,6 +67,42 @@ func NackPairsFromSequenceNumbers(sequenceNumbers []uint16) (pairs []NackPair) {
 	return
 }
 
+// SequenceNumbersFromNacks returns the sequence numbers requested by the given
+// TransportLayerNacks, which should all concern the same media source. They are
+// deduplicated and ordered in serial number arithmetic, oldest first.
+func SequenceNumbersFromNacks(nacks ...*TransportLayerNack) []uint16 {
+	var sequenceNumbers []uint16
+	for _, nack := range nacks {
+		for i := range nack.Nacks {
+			sequenceNumbers = append(sequenceNumbers, nack.Nacks[i].PacketList()...)
+		}
+	}
+
+	return serialSortSequenceNumbers(sequenceNumbers)
+}
+
+// serialSortSequenceNumbers returns a sorted copy of sequenceNumbers without
+// duplicates. As serial number arithmetic only orders numbers less than half
+// the sequence number space apart, the list starts after the largest gap
+// between consecutive sequence numbers, which is where it wraps around.
+func serialSortSequenceNumbers(sequenceNumbers []uint16) []uint16 {
+	sorted := slices.Clone(sequenceNumbers)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+	if len(sorted) < 2 {
+		return sorted
+	}
+
+	start, gap := 0, sorted[0]-sorted[len(sorted)-1]
+	for i := 1; i < len(sorted); i++ {
+		if d := sorted[i] - sorted[i-1]; d > gap {
+			start, gap = i, d
+		}
+	}
+
+	return append(sorted[start:len(sorted):len(sorted)], sorted[:start]...)
+}
+
 // Range calls f sequentially for each sequence number covered by n.
 // If f returns false, Range stops the iteration.
 func (n *NackPair) Range(f func(seqno uint16) bool) {
@@ -4,6 +4,7 @@
 package rtcp
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -191,3 +192,106 @@ func TestTransportLayerNackPairGeneration(t *testing.T) {
 		assert.Equalf(t, test.Expected, actual, "%q NackPair generation mismatch", test.Name)
 	}
 }
+
+func TestTransportLayerNackPairGenerationUnordered(t *testing.T) {
+	for _, test := range []struct {
+		Name            string
+		SequenceNumbers []uint16
+		Expected        []NackPair
+	}{
+		{
+			"Unsorted",
+			[]uint16{115, 100, 105, 101},
+			[]NackPair{{PacketID: 100, LostPackets: 0x4011}},
+		},
+		{
+			"Duplicates",
+			[]uint16{100, 100, 101, 101, 117},
+			[]NackPair{{PacketID: 100, LostPackets: 0x1}, {PacketID: 117}},
+		},
+		{
+			"Wraparound",
+			[]uint16{65535, 0, 1},
+			[]NackPair{{PacketID: 65535, LostPackets: 0x3}},
+		},
+		{
+			"Wraparound, numerically sorted",
+			[]uint16{0, 1, 65534, 65535},
+			[]NackPair{{PacketID: 65534, LostPackets: 0x7}},
+		},
+		{
+			"Wraparound, shuffled with duplicates",
+			[]uint16{20, 65530, 3, 65530, 65535, 20},
+			[]NackPair{{PacketID: 65530, LostPackets: 0x110}, {PacketID: 20}},
+		},
+		{
+			"Half the sequence number space apart",
+			[]uint16{32768, 0},
+			[]NackPair{{PacketID: 0}, {PacketID: 32768}},
+		},
+	} {
+		actual := NackPairsFromSequenceNumbers(test.SequenceNumbers)
+		assert.Equalf(t, test.Expected, actual, "%q NackPair generation mismatch", test.Name)
+	}
+}
+
+func TestSequenceNumbersFromNacks(t *testing.T) {
+	assert.Empty(t, SequenceNumbersFromNacks())
+	assert.Equal(t, []uint16{65534, 65535, 0, 5, 30}, SequenceNumbersFromNacks(
+		&TransportLayerNack{Nacks: []NackPair{{PacketID: 0, LostPackets: 0x10}, {PacketID: 30}}},
+		&TransportLayerNack{Nacks: []NackPair{{PacketID: 65534, LostPackets: 0x3}}},
+		&TransportLayerNack{Nacks: []NackPair{{PacketID: 5}}},
+	))
+}
+
+// minimalNackPairs counts the pairs needed for the serially ordered,
+// deduplicated sequence numbers seqs.
+func minimalNackPairs(seqs []uint16) int {
+	count := 0
+	for i := 0; i < len(seqs); {
+		count++
+		start := seqs[i]
+		for i < len(seqs) && seqs[i]-start <= 16 {
+			i++
+		}
+	}
+
+	return count
+}
+
+func TestTransportLayerNackWraparoundExhaustive(t *testing.T) {
+	// Every run of consecutive sequence numbers starting around the wrap,
+	// given in reverse order and duplicated.
+	for start := uint16(65536 - 40); start != 40; start++ {
+		for length := 1; length <= 40; length++ {
+			var want, input []uint16
+			for i := 0; i < length; i++ {
+				seq := start + uint16(i) //nolint:gosec // G115
+				want = append(want, seq)
+				input = append([]uint16{seq, seq}, input...)
+			}
+
+			pairs := NackPairsFromSequenceNumbers(input)
+			assert.Equal(t, start, pairs[0].PacketID)
+			assert.Len(t, pairs, (length+16)/17)
+			assert.Equal(t, want, SequenceNumbersFromNacks(&TransportLayerNack{Nacks: pairs}))
+		}
+	}
+
+	// Sparse sets spanning the wrap, shuffled.
+	rng := rand.New(rand.NewSource(1)) //nolint:gosec // G404
+	for i := 0; i < 2000; i++ {
+		start := uint16(65536 - rng.Intn(200)) //nolint:gosec // G115
+		var want []uint16
+		for seq, n := start, rng.Intn(60)+1; len(want) < n; seq += uint16(rng.Intn(20) + 1) { //nolint:gosec // G115
+			want = append(want, seq)
+		}
+		input := append([]uint16(nil), want...)
+		input = append(input, want[:len(want)/2]...)
+		rng.Shuffle(len(input), func(a, b int) { input[a], input[b] = input[b], input[a] })
+
+		pairs := NackPairsFromSequenceNumbers(input)
+		assert.Equal(t, want, SequenceNumbersFromNacks(&TransportLayerNack{Nacks: pairs}))
+		assert.Len(t, pairs, minimalNackPairs(want))
+	}
+}
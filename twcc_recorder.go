@@ -0,0 +1,480 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"time"
+)
+
+const (
+	// tccBaseTimeTick is the unit of the reference time, in microseconds.
+	tccBaseTimeTick = 64000
+	// tccTimeWrapPeriod is the period of the 24-bit reference time, in
+	// microseconds.
+	tccTimeWrapPeriod = tccBaseTimeTick << 24
+	// tccHeaderLength is the length of a TransportLayerCC without chunks
+	// and deltas.
+	tccHeaderLength = headerLength + packetChunkOffset
+	// Capacity of a packet status chunk, in packets.
+	tccMaxRunLength       = 0x1FFF
+	tccMaxOneBitCapacity  = 14
+	tccMaxTwoBitCapacity  = 7
+	tccMaxReportedPackets = 0xFFFF
+)
+
+// Defaults and limits of a TWCCRecorder.
+const (
+	defaultTWCCMTU = 1200
+	// twccMaxWindow is the maximum number of sequence numbers tracked.
+	twccMaxWindow = 1 << 15
+)
+
+// TWCCRecorder records the arrival times of the RTP packets carrying a
+// transport-wide sequence number and builds the TransportLayerCC packets
+// reporting them, as a receiver implementing
+// draft-holmer-rmcat-transport-wide-cc-extensions-01 does.
+//
+// The packets are encoded as libwebrtc encodes them: a chunk is kept open
+// while symbols can be added to it and is then emitted as a RunLengthChunk
+// if all its symbols are the same, or as a 1-bit or 2-bit StatusVectorChunk
+// otherwise. The reference time is taken from the first packet received,
+// and deltas are rounded to 250µs, with the error carried over to the next
+// delta. A new TransportLayerCC is started when a delta does not fit in 16
+// bits or the packet would exceed MTU. Each packet carries the next
+// FbPktCount.
+//
+// Packets arriving after a newer packet has already been reported cause the
+// packets following them to be reported again, so that the sender learns
+// about the reordering.
+type TWCCRecorder struct {
+	// SSRC of the sender of the feedback, and of the media source.
+	SenderSSRC uint32
+	MediaSSRC  uint32
+	// MTU is the maximum size of a TransportLayerCC.
+	MTU int
+
+	fbPktCount uint8
+	window     *arrivalWindow
+}
+
+// NewTWCCRecorder creates a TWCCRecorder reporting the packets received on
+// the media source mediaSSRC from senderSSRC.
+func NewTWCCRecorder(senderSSRC, mediaSSRC uint32) *TWCCRecorder {
+	return &TWCCRecorder{
+		SenderSSRC: senderSSRC,
+		MediaSSRC:  mediaSSRC,
+		MTU:        defaultTWCCMTU,
+		window:     newArrivalWindow(twccMaxWindow),
+	}
+}
+
+// Record records the arrival of the packet with transport-wide sequence
+// number seq at time arrival. Duplicates are ignored.
+func (r *TWCCRecorder) Record(seq uint16, arrival time.Time) {
+	r.window.record(seq, packetArrival{at: arrival})
+}
+
+// BuildFeedbackPackets returns the TransportLayerCC packets reporting the
+// packets received since the last call, or nil if there are none.
+func (r *TWCCRecorder) BuildFeedbackPackets() []Packet {
+	if !r.window.pending() {
+		return nil
+	}
+
+	tccs := buildTransportLayerCCs(r.window.start, r.window.highest, func(seq int64) (time.Time, bool) {
+		arrival, ok := r.window.arrivals[seq]
+
+		return arrival.at, ok
+	}, r.MTU, r.SenderSSRC, r.MediaSSRC, &r.fbPktCount)
+	r.window.start = r.window.highest + 1
+
+	out := make([]Packet, 0, len(tccs))
+	for _, tcc := range tccs {
+		out = append(out, tcc)
+	}
+
+	return out
+}
+
+// buildTransportLayerCCs returns the TransportLayerCC packets reporting the
+// packets with transport-wide sequence numbers from start to end, whose
+// arrival times are returned by arrival, numbered from *fbPktCount.
+// Packets that cannot be reported at all are left out.
+func buildTransportLayerCCs(
+	start, end int64,
+	arrival func(seq int64) (time.Time, bool),
+	mtu int,
+	senderSSRC, mediaSSRC uint32,
+	fbPktCount *uint8,
+) []*TransportLayerCC {
+	var out []*TransportLayerCC
+	for start <= end {
+		builder := &tccBuilder{maxSize: mtu}
+		next := start
+		for s := start; s <= end; s++ {
+			at, ok := arrival(s)
+			if !ok {
+				continue
+			}
+			if builder.size == 0 {
+				builder.setBase(uint16(start), at) //nolint:gosec // G115
+			}
+			if !builder.addReceivedPacket(uint16(s), at) { //nolint:gosec // G115
+				break
+			}
+			next = s + 1
+		}
+		if builder.size == 0 {
+			// Only lost packets are left.
+			break
+		}
+		if next == start {
+			start++
+
+			continue
+		}
+		start = next
+
+		out = append(out, builder.build(senderSSRC, mediaSSRC, *fbPktCount))
+		*fbPktCount++
+	}
+
+	return out
+}
+
+// Limits of an arrivalWindow.
+const (
+	// arrivalBackWindow is how long reported packets are remembered, so
+	// that the packets around a late one can be reported again.
+	arrivalBackWindow = 500 * time.Millisecond
+)
+
+type packetArrival struct {
+	at  time.Time
+	ecn ECN
+}
+
+// arrivalWindow records the packets received on a stream, by unwrapped
+// sequence number. The packets from start to highest are yet to be
+// reported. A packet older than start moves start back, so that the packets
+// after it are reported again. Packets are evicted in sequence number order
+// from oldest, so that recording a packet takes amortized constant time.
+type arrivalWindow struct {
+	maxSize  int64
+	started  bool
+	highest  int64
+	start    int64
+	oldest   int64
+	arrivals map[int64]packetArrival
+}
+
+func newArrivalWindow(maxSize int64) *arrivalWindow {
+	return &arrivalWindow{maxSize: maxSize, arrivals: map[int64]packetArrival{}}
+}
+
+func (w *arrivalWindow) record(seq uint16, arrival packetArrival) {
+	if !w.started {
+		w.started = true
+		w.highest = int64(seq)
+		w.start = int64(seq)
+		w.oldest = int64(seq)
+	}
+
+	unwrapped := w.highest + int64(int16(seq-uint16(w.highest))) //nolint:gosec // G115, serial number arithmetic
+	if w.highest-unwrapped >= w.maxSize {
+		return
+	}
+	if _, ok := w.arrivals[unwrapped]; ok {
+		return
+	}
+	w.arrivals[unwrapped] = arrival
+	w.start = min(w.start, unwrapped)
+	w.highest = max(w.highest, unwrapped)
+	w.oldest = min(w.oldest, unwrapped)
+	w.start = max(w.start, w.highest-w.maxSize+1)
+
+	// Evict the packets out of the window, and the reported packets older
+	// than arrivalBackWindow, up to the first one to keep.
+	for w.oldest < w.start {
+		previous, ok := w.arrivals[w.oldest]
+		if ok && w.oldest > w.highest-w.maxSize && arrival.at.Sub(previous.at) <= arrivalBackWindow {
+			break
+		}
+		delete(w.arrivals, w.oldest)
+		w.oldest++
+	}
+}
+
+// pending reports whether packets are yet to be reported.
+func (w *arrivalWindow) pending() bool {
+	return w.started && w.start <= w.highest
+}
+
+// tccChunk is the packet status chunk being filled, holding the symbols of
+// the packets added to it.
+type tccChunk struct {
+	symbols  [tccMaxOneBitCapacity]uint16
+	size     int
+	allSame  bool
+	hasLarge bool
+}
+
+func (c *tccChunk) clear() {
+	c.size = 0
+	c.allSame = true
+	c.hasLarge = false
+}
+
+func (c *tccChunk) canAdd(symbol uint16) bool {
+	switch {
+	case c.size < tccMaxTwoBitCapacity:
+		return true
+	case c.size < tccMaxOneBitCapacity && !c.hasLarge && symbol != TypeTCCPacketReceivedLargeDelta:
+		return true
+	default:
+		return c.size < tccMaxRunLength && c.allSame && c.symbols[0] == symbol
+	}
+}
+
+func (c *tccChunk) add(symbol uint16) {
+	if c.size < tccMaxOneBitCapacity {
+		c.symbols[c.size] = symbol
+	}
+	c.size++
+	c.allSame = c.allSame && symbol == c.symbols[0]
+	c.hasLarge = c.hasLarge || symbol == TypeTCCPacketReceivedLargeDelta
+}
+
+func (c *tccChunk) addMissing(count int) {
+	for i := 0; i < min(count, tccMaxOneBitCapacity); i++ {
+		c.symbols[i] = TypeTCCPacketNotReceived
+	}
+	c.size = count
+	c.allSame = true
+	c.hasLarge = false
+}
+
+// emit encodes the chunk once it is full. A full 2-bit chunk keeps the
+// symbols that did not fit.
+func (c *tccChunk) emit() PacketStatusChunk {
+	if c.allSame {
+		chunk := c.runLength()
+		c.clear()
+
+		return chunk
+	}
+	if c.size == tccMaxOneBitCapacity {
+		chunk := c.vector(TypeTCCSymbolSizeOneBit, tccMaxOneBitCapacity, tccMaxOneBitCapacity)
+		c.clear()
+
+		return chunk
+	}
+
+	chunk := c.vector(TypeTCCSymbolSizeTwoBit, tccMaxTwoBitCapacity, tccMaxTwoBitCapacity)
+	rest := append([]uint16(nil), c.symbols[tccMaxTwoBitCapacity:c.size]...)
+	c.clear()
+	for _, symbol := range rest {
+		c.add(symbol)
+	}
+
+	return chunk
+}
+
+// emitLast encodes the last, possibly incomplete, chunk.
+func (c *tccChunk) emitLast() PacketStatusChunk {
+	switch {
+	case c.allSame:
+		return c.runLength()
+	case c.size <= tccMaxTwoBitCapacity:
+		return c.vector(TypeTCCSymbolSizeTwoBit, c.size, tccMaxTwoBitCapacity)
+	default:
+		return c.vector(TypeTCCSymbolSizeOneBit, c.size, tccMaxOneBitCapacity)
+	}
+}
+
+func (c *tccChunk) runLength() PacketStatusChunk {
+	return &RunLengthChunk{
+		Type:               TypeTCCRunLengthChunk,
+		PacketStatusSymbol: c.symbols[0],
+		RunLength:          uint16(c.size), //nolint:gosec // G115
+	}
+}
+
+func (c *tccChunk) vector(symbolSize uint16, size, capacity int) PacketStatusChunk {
+	symbols := make([]uint16, capacity)
+	copy(symbols, c.symbols[:size])
+
+	return &StatusVectorChunk{
+		Type:       TypeTCCStatusVectorChunk,
+		SymbolSize: symbolSize,
+		SymbolList: symbols,
+	}
+}
+
+// tccBuilder builds one TransportLayerCC, following libwebrtc's
+// rtcp::TransportFeedback.
+type tccBuilder struct {
+	maxSize       int
+	baseSeq       uint16
+	baseTime      uint32
+	lastTimestamp int64
+	numSeq        int
+	size          int
+	chunks        []PacketStatusChunk
+	last          tccChunk
+	deltas        []*RecvDelta
+}
+
+func (b *tccBuilder) setBase(seq uint16, reference time.Time) {
+	b.baseSeq = seq
+	b.baseTime = uint32(reference.UnixMicro() % tccTimeWrapPeriod / tccBaseTimeTick) //nolint:gosec // G115
+	b.lastTimestamp = int64(b.baseTime) * tccBaseTimeTick
+	b.size = tccHeaderLength
+	b.last.clear()
+}
+
+func (b *tccBuilder) addReceivedPacket(seq uint16, arrival time.Time) bool {
+	deltaFull := (arrival.UnixMicro() - b.lastTimestamp) % tccTimeWrapPeriod
+	if deltaFull < 0 {
+		// Normalize, so that deltas are rounded the same whatever the epoch.
+		deltaFull += tccTimeWrapPeriod
+	}
+	if deltaFull > tccTimeWrapPeriod/2 {
+		deltaFull -= tccTimeWrapPeriod + TypeTCCDeltaScaleFactor/2
+	} else {
+		deltaFull += TypeTCCDeltaScaleFactor / 2
+	}
+	deltaFull /= TypeTCCDeltaScaleFactor
+
+	delta := int16(deltaFull) //nolint:gosec // G115, checked below
+	if int64(delta) != deltaFull {
+		return false
+	}
+
+	next := b.baseSeq + uint16(b.numSeq) //nolint:gosec // G115
+	if seq != next {
+		if int16(seq-(next-1)) <= 0 { //nolint:gosec // G115
+			return false
+		}
+		if !b.addMissingPackets(int(seq - next)) {
+			return false
+		}
+	}
+
+	symbol := uint16(TypeTCCPacketReceivedLargeDelta)
+	if delta >= 0 && delta <= 0xFF {
+		symbol = TypeTCCPacketReceivedSmallDelta
+	}
+	if !b.addSymbol(symbol) {
+		return false
+	}
+
+	b.deltas = append(b.deltas, &RecvDelta{Type: symbol, Delta: int64(delta) * TypeTCCDeltaScaleFactor})
+	b.lastTimestamp += int64(delta) * TypeTCCDeltaScaleFactor
+
+	return true
+}
+
+func (b *tccBuilder) addSymbol(symbol uint16) bool {
+	if b.numSeq == tccMaxReportedPackets {
+		return false
+	}
+
+	deltaSize := int(symbol)
+	chunkSize := 0
+	if b.last.size == 0 {
+		chunkSize = packetStatusChunkLength
+	}
+	if b.size+deltaSize+chunkSize > b.maxSize {
+		return false
+	}
+	if b.last.canAdd(symbol) {
+		b.size += chunkSize + deltaSize
+		b.last.add(symbol)
+		b.numSeq++
+
+		return true
+	}
+	if b.size+deltaSize+packetStatusChunkLength > b.maxSize {
+		return false
+	}
+
+	b.chunks = append(b.chunks, b.last.emit())
+	b.size += packetStatusChunkLength + deltaSize
+	b.last.add(symbol)
+	b.numSeq++
+
+	return true
+}
+
+func (b *tccBuilder) addMissingPackets(count int) bool {
+	numSeq := b.numSeq + count
+	if numSeq > tccMaxReportedPackets {
+		return false
+	}
+
+	if b.last.size != 0 {
+		for count > 0 && b.last.canAdd(TypeTCCPacketNotReceived) {
+			b.last.add(TypeTCCPacketNotReceived)
+			count--
+		}
+		if count == 0 {
+			b.numSeq = numSeq
+
+			return true
+		}
+		b.chunks = append(b.chunks, b.last.emit())
+	}
+
+	fullChunks := count / tccMaxRunLength
+	partialChunk := count % tccMaxRunLength
+	numChunks := fullChunks
+	if partialChunk > 0 {
+		numChunks++
+	}
+	if b.size+numChunks*packetStatusChunkLength > b.maxSize {
+		b.numSeq = numSeq - count
+
+		return false
+	}
+
+	b.size += numChunks * packetStatusChunkLength
+	for i := 0; i < fullChunks; i++ {
+		b.chunks = append(b.chunks, &RunLengthChunk{
+			Type:               TypeTCCRunLengthChunk,
+			PacketStatusSymbol: TypeTCCPacketNotReceived,
+			RunLength:          tccMaxRunLength,
+		})
+	}
+	b.last.addMissing(partialChunk)
+	b.numSeq = numSeq
+
+	return true
+}
+
+func (b *tccBuilder) build(senderSSRC, mediaSSRC uint32, fbPktCount uint8) *TransportLayerCC {
+	chunks := b.chunks
+	if b.last.size > 0 {
+		chunks = append(chunks, b.last.emitLast())
+	}
+
+	packet := &TransportLayerCC{
+		SenderSSRC:         senderSSRC,
+		MediaSSRC:          mediaSSRC,
+		BaseSequenceNumber: b.baseSeq,
+		PacketStatusCount:  uint16(b.numSeq), //nolint:gosec // G115
+		ReferenceTime:      b.baseTime,
+		FbPktCount:         fbPktCount,
+		PacketChunks:       chunks,
+		RecvDeltas:         b.deltas,
+	}
+	packet.Header = Header{
+		Padding: packet.packetLen()%4 != 0,
+		Count:   FormatTCC,
+		Type:    TypeTransportSpecificFeedback,
+		Length:  uint16(packet.MarshalSize()/4 - 1), //nolint:gosec // G115
+	}
+
+	return packet
+}
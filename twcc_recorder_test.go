@@ -0,0 +1,327 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// twccEpoch is a reference time of 1000 in 64ms units.
+var twccEpoch = time.UnixMicro(1000 * tccBaseTimeTick) //nolint:gochecknoglobals
+
+func buildTWCC(t *testing.T, recorder *TWCCRecorder) []*TransportLayerCC {
+	t.Helper()
+
+	var out []*TransportLayerCC
+	for _, pkt := range recorder.BuildFeedbackPackets() {
+		tcc, ok := pkt.(*TransportLayerCC)
+		assert.True(t, ok)
+
+		// Every packet survives a round trip.
+		raw, err := tcc.Marshal()
+		assert.NoError(t, err)
+		assert.Len(t, raw, tcc.MarshalSize())
+		decoded := &TransportLayerCC{}
+		assert.NoError(t, decoded.Unmarshal(raw))
+		assert.Equal(t, tcc, decoded)
+
+		out = append(out, tcc)
+	}
+
+	return out
+}
+
+func TestTWCCRecorderMarshal(t *testing.T) {
+	recorder := NewTWCCRecorder(0x1, 0x2)
+	recorder.Record(100, twccEpoch)
+	recorder.Record(101, twccEpoch.Add(time.Millisecond))
+	recorder.Record(102, twccEpoch.Add(2500*time.Microsecond))
+	recorder.Record(101, twccEpoch.Add(time.Second))
+
+	pkts := recorder.BuildFeedbackPackets()
+	assert.Len(t, pkts, 1)
+	raw, err := pkts[0].Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{
+		0xaf, 0xcd, 0x00, 0x06,
+		0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x02,
+		0x00, 0x64, 0x00, 0x03,
+		0x00, 0x03, 0xe8, 0x00,
+		// Run length chunk, 3 packets with small deltas.
+		0x20, 0x03,
+		// Deltas of 0, 1 and 1.5ms.
+		0x00, 0x04, 0x06,
+		0x00, 0x00, 0x03,
+	}, raw)
+
+	assert.Empty(t, recorder.BuildFeedbackPackets())
+	assert.Empty(t, NewTWCCRecorder(0x1, 0x2).BuildFeedbackPackets())
+}
+
+func TestTWCCRecorderFixtures(t *testing.T) {
+	// Packets of TestTransportLayerCC_Unmarshal, rebuilt from the arrival
+	// times they report.
+	for _, test := range []struct {
+		Name          string
+		MediaSSRC     uint32
+		BaseSequence  uint16
+		ReferenceTime int64
+		FbPktCount    uint8
+		Deltas        []time.Duration
+		Data          []byte
+	}{
+		{
+			Name:          "example1",
+			MediaSSRC:     0x43032fa0,
+			BaseSequence:  153,
+			ReferenceTime: 4057090,
+			FbPktCount:    23,
+			Deltas:        []time.Duration{37 * time.Millisecond},
+			Data: []byte{
+				0xaf, 0xcd, 0x0, 0x5,
+				0xfa, 0x17, 0xfa, 0x17,
+				0x43, 0x3, 0x2f, 0xa0,
+				0x0, 0x99, 0x0, 0x1,
+				0x3d, 0xe8, 0x2, 0x17,
+				0x20, 0x1, 0x94, 0x1,
+			},
+		},
+		{
+			Name:          "example4",
+			MediaSSRC:     0x193dd8bb,
+			BaseSequence:  4,
+			ReferenceTime: 1074030,
+			FbPktCount:    1,
+			Deltas: []time.Duration{
+				19 * time.Millisecond, 9 * time.Millisecond, 9 * time.Millisecond, 4 * time.Millisecond,
+				3 * time.Millisecond, 3 * time.Millisecond, 4 * time.Millisecond,
+			},
+			Data: []byte{
+				0xaf, 0xcd, 0x0, 0x7,
+				0xfa, 0x17, 0xfa, 0x17,
+				0x19, 0x3d, 0xd8, 0xbb,
+				0x0, 0x4, 0x0, 0x7,
+				0x10, 0x63, 0x6e, 0x1,
+				0x20, 0x7, 0x4c, 0x24,
+				0x24, 0x10, 0xc, 0xc,
+				0x10, 0x0, 0x0, 0x3,
+			},
+		},
+	} {
+		recorder := NewTWCCRecorder(0xfa17fa17, test.MediaSSRC)
+		recorder.fbPktCount = test.FbPktCount
+		arrival := time.UnixMicro(test.ReferenceTime * tccBaseTimeTick)
+		for i, delta := range test.Deltas {
+			arrival = arrival.Add(delta)
+			recorder.Record(test.BaseSequence+uint16(i), arrival) //nolint:gosec // G115
+		}
+
+		pkts := recorder.BuildFeedbackPackets()
+		if assert.Len(t, pkts, 1, test.Name) {
+			raw, err := pkts[0].Marshal()
+			assert.NoError(t, err, test.Name)
+			assert.Equal(t, test.Data, raw, test.Name)
+		}
+	}
+}
+
+func TestTWCCRecorderChunks(t *testing.T) {
+	for _, test := range []struct {
+		Name     string
+		Arrivals map[uint16]time.Duration
+		Chunks   []PacketStatusChunk
+		Deltas   []*RecvDelta
+	}{
+		{
+			Name: "two-bit vector",
+			Arrivals: map[uint16]time.Duration{
+				0: 0,
+				2: 70 * time.Millisecond,
+				3: 71 * time.Millisecond,
+			},
+			Chunks: []PacketStatusChunk{
+				&StatusVectorChunk{
+					Type:       TypeTCCStatusVectorChunk,
+					SymbolSize: TypeTCCSymbolSizeTwoBit,
+					SymbolList: []uint16{1, 0, 2, 1, 0, 0, 0},
+				},
+			},
+			Deltas: []*RecvDelta{
+				{Type: TypeTCCPacketReceivedSmallDelta, Delta: 0},
+				{Type: TypeTCCPacketReceivedLargeDelta, Delta: 70000},
+				{Type: TypeTCCPacketReceivedSmallDelta, Delta: 1000},
+			},
+		},
+		{
+			Name: "one-bit vector",
+			Arrivals: map[uint16]time.Duration{
+				0: 0, 2: 0, 4: 0, 6: 0, 8: 0, 10: 0, 12: 0, 14: 0, 16: 0,
+			},
+			Chunks: []PacketStatusChunk{
+				&StatusVectorChunk{
+					Type:       TypeTCCStatusVectorChunk,
+					SymbolSize: TypeTCCSymbolSizeOneBit,
+					SymbolList: []uint16{1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0},
+				},
+				&StatusVectorChunk{
+					Type:       TypeTCCStatusVectorChunk,
+					SymbolSize: TypeTCCSymbolSizeTwoBit,
+					SymbolList: []uint16{1, 0, 1, 0, 0, 0, 0},
+				},
+			},
+		},
+		{
+			Name: "run of lost packets",
+			Arrivals: map[uint16]time.Duration{
+				0:   0,
+				100: 0,
+			},
+			Chunks: []PacketStatusChunk{
+				&StatusVectorChunk{
+					Type:       TypeTCCStatusVectorChunk,
+					SymbolSize: TypeTCCSymbolSizeOneBit,
+					SymbolList: []uint16{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+				},
+				&RunLengthChunk{Type: TypeTCCRunLengthChunk, PacketStatusSymbol: TypeTCCPacketNotReceived, RunLength: 86},
+				&RunLengthChunk{Type: TypeTCCRunLengthChunk, PacketStatusSymbol: TypeTCCPacketReceivedSmallDelta, RunLength: 1},
+			},
+		},
+		{
+			Name: "sequence number wraparound",
+			Arrivals: map[uint16]time.Duration{
+				65534: 0,
+				65535: time.Millisecond,
+				0:     2 * time.Millisecond,
+				1:     3 * time.Millisecond,
+			},
+			Chunks: []PacketStatusChunk{
+				&RunLengthChunk{Type: TypeTCCRunLengthChunk, PacketStatusSymbol: TypeTCCPacketReceivedSmallDelta, RunLength: 4},
+			},
+		},
+	} {
+		recorder := NewTWCCRecorder(0x1, 0x2)
+		for _, seq := range []uint16{65534, 65535, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 100} {
+			if offset, ok := test.Arrivals[seq]; ok {
+				recorder.Record(seq, twccEpoch.Add(offset))
+			}
+		}
+
+		pkts := buildTWCC(t, recorder)
+		if assert.Len(t, pkts, 1, test.Name) {
+			assert.Equal(t, test.Chunks, pkts[0].PacketChunks, test.Name)
+			assert.Equal(t, uint32(1000), pkts[0].ReferenceTime, test.Name)
+			assert.Len(t, pkts[0].RecvDeltas, len(test.Arrivals), test.Name)
+			if test.Deltas != nil {
+				assert.Equal(t, test.Deltas, pkts[0].RecvDeltas, test.Name)
+			}
+		}
+	}
+}
+
+func TestTWCCRecorderReordering(t *testing.T) {
+	recorder := NewTWCCRecorder(0x1, 0x2)
+	recorder.Record(11, twccEpoch.Add(time.Millisecond))
+	recorder.Record(13, twccEpoch.Add(2*time.Millisecond))
+	recorder.Record(12, twccEpoch.Add(3*time.Millisecond))
+
+	pkts := buildTWCC(t, recorder)
+	assert.Len(t, pkts, 1)
+	assert.Equal(t, uint16(11), pkts[0].BaseSequenceNumber)
+	assert.Equal(t, []*RecvDelta{
+		{Type: TypeTCCPacketReceivedSmallDelta, Delta: 1000},
+		{Type: TypeTCCPacketReceivedSmallDelta, Delta: 2000},
+		{Type: TypeTCCPacketReceivedLargeDelta, Delta: -1000},
+	}, pkts[0].RecvDeltas)
+
+	// A packet older than those reported causes them to be reported again.
+	recorder.Record(10, twccEpoch.Add(4*time.Millisecond))
+	pkts = buildTWCC(t, recorder)
+	assert.Len(t, pkts, 1)
+	assert.Equal(t, uint16(10), pkts[0].BaseSequenceNumber)
+	assert.Equal(t, uint16(4), pkts[0].PacketStatusCount)
+	assert.Equal(t, uint8(1), pkts[0].FbPktCount)
+}
+
+func TestTWCCRecorderEviction(t *testing.T) {
+	recorder := NewTWCCRecorder(0x1, 0x2)
+	for seq := uint16(0); seq < 100; seq++ {
+		recorder.Record(seq, twccEpoch.Add(time.Duration(seq)*10*time.Millisecond))
+		buildTWCC(t, recorder)
+	}
+
+	// Only the reported packets received within the last 500ms are kept.
+	assert.Len(t, recorder.window.arrivals, 51)
+	assert.Equal(t, int64(49), recorder.window.oldest)
+
+	// A late packet reopens the packets still kept.
+	recorder.Record(40, twccEpoch.Add(time.Second))
+	pkts := buildTWCC(t, recorder)
+	assert.Len(t, pkts, 1)
+	assert.Equal(t, uint16(40), pkts[0].BaseSequenceNumber)
+	assert.Equal(t, uint16(60), pkts[0].PacketStatusCount)
+
+	// Packets out of the window are evicted.
+	recorder.Record(20000, twccEpoch.Add(time.Second))
+	recorder.Record(40000, twccEpoch.Add(time.Second))
+	assert.Len(t, recorder.window.arrivals, 2)
+	assert.Equal(t, int64(40000-twccMaxWindow+1), recorder.window.oldest)
+	assert.Equal(t, int64(40000-twccMaxWindow+1), recorder.window.start)
+}
+
+func TestTWCCRecorderNegativeDeltaRounding(t *testing.T) {
+	// As in libwebrtc's TransportFeedback::AddReceivedPacket, deltas are
+	// rounded to the nearest 250µs, away from zero at the midpoint, the
+	// same way whatever the epoch of the arrival times.
+	for _, epoch := range []time.Time{twccEpoch, time.Unix(1700000000, 0)} {
+		recorder := NewTWCCRecorder(0x1, 0x2)
+		recorder.Record(1, epoch.Add(10*time.Millisecond))
+		recorder.Record(2, epoch.Add(9*time.Millisecond))
+		recorder.Record(3, epoch.Add(8900*time.Microsecond))
+		recorder.Record(4, epoch.Add(8600*time.Microsecond))
+		recorder.Record(5, epoch.Add(8375*time.Microsecond))
+
+		pkts := buildTWCC(t, recorder)
+		if assert.Len(t, pkts, 1, epoch) {
+			deltas := pkts[0].RecvDeltas
+			assert.Len(t, deltas, 5, epoch)
+			assert.Equal(t, int64(-1000), deltas[1].Delta, epoch)
+			assert.Equal(t, int64(0), deltas[2].Delta, epoch)
+			assert.Equal(t, int64(-500), deltas[3].Delta, epoch)
+			assert.Equal(t, int64(-250), deltas[4].Delta, epoch)
+		}
+	}
+}
+
+func TestTWCCRecorderSplit(t *testing.T) {
+	recorder := NewTWCCRecorder(0x1, 0x2)
+	recorder.MTU = 40
+	for seq := uint16(0); seq < 30; seq++ {
+		recorder.Record(seq, twccEpoch.Add(time.Duration(seq)*time.Millisecond))
+	}
+
+	// The header and one chunk leave room for 18 deltas.
+	pkts := buildTWCC(t, recorder)
+	assert.Len(t, pkts, 2)
+	assert.Equal(t, uint16(0), pkts[0].BaseSequenceNumber)
+	assert.Equal(t, uint16(18), pkts[0].PacketStatusCount)
+	assert.Equal(t, uint8(0), pkts[0].FbPktCount)
+	assert.Equal(t, 40, pkts[0].MarshalSize())
+	assert.Equal(t, uint16(18), pkts[1].BaseSequenceNumber)
+	assert.Equal(t, uint16(12), pkts[1].PacketStatusCount)
+	assert.Equal(t, uint8(1), pkts[1].FbPktCount)
+	assert.Equal(t, uint32(1000), pkts[1].ReferenceTime)
+
+	// Deltas that do not fit in 16 bits start a new packet.
+	recorder.Record(30, twccEpoch.Add(10*time.Second))
+	recorder.Record(31, twccEpoch.Add(20*time.Second))
+	pkts = buildTWCC(t, recorder)
+	assert.Len(t, pkts, 2)
+	assert.Equal(t, uint32(1156), pkts[0].ReferenceTime)
+	assert.Equal(t, uint32(1312), pkts[1].ReferenceTime)
+	assert.Equal(t, uint8(3), pkts[1].FbPktCount)
+}